@@ -0,0 +1,110 @@
+package protoscan
+
+// DeltaSint64 reads the next sint64 value and adds it to a running sum
+// of the values read so far, returning the sum. This decodes packed fields
+// where each value is stored as the difference from the previous value.
+// The values moved over by Skip, Seek or Reset are added to, or removed
+// from, the sum before reading the next value.
+func (i *Iterator) DeltaSint64() (int64, error) {
+	if err := i.updateDelta(); err != nil {
+		return 0, err
+	}
+
+	v, err := i.Sint64()
+	if err != nil {
+		return 0, err
+	}
+
+	i.delta += v
+	i.deltaIndex = i.Index
+	return i.delta, nil
+}
+
+// DeltaSint32 reads the next sint32 value and adds it to a running sum,
+// see DeltaSint64.
+func (i *Iterator) DeltaSint32() (int32, error) {
+	if err := i.updateDelta(); err != nil {
+		return 0, err
+	}
+
+	v, err := i.Sint32()
+	if err != nil {
+		return 0, err
+	}
+
+	i.delta = int64(int32(i.delta) + v)
+	i.deltaIndex = i.Index
+	return int32(i.delta), nil
+}
+
+// updateDelta brings the running sum up to the current index if the iterator
+// was moved without reading the values, by Skip, Seek or Reset. Moving backwards
+// the sum is recomputed from the first value.
+func (i *Iterator) updateDelta() error {
+	if i.deltaIndex == i.Index {
+		return nil
+	}
+
+	if i.deltaIndex > i.Index {
+		i.delta = 0
+		i.deltaIndex = 0
+	}
+
+	index := i.deltaIndex
+	for index < i.Index {
+		next, v, err := varint64(i.Data, index)
+		if err != nil {
+			return i.decodeError(err)
+		}
+
+		i.delta += unZig64(v)
+		index = next
+	}
+
+	i.deltaIndex = index
+	return nil
+}
+
+// RepeatedDeltaSint64 will append the repeated, delta encoded, value(s) to
+// the buffer. Each value is the difference from the previous value of the
+// field, the running sum starts at zero. This method supports packed or
+// unpacked encoding, but an unpacked value is its own delta from zero.
+func (m *Message) RepeatedDeltaSint64(buf []int64) ([]int64, error) {
+	if m.wireType == WireTypeVarint {
+		v, err := m.Sint64()
+		if err != nil {
+			return nil, err
+		}
+
+		return append(buf, v), nil
+	}
+
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
+	l, err := m.packedLength()
+	if err != nil {
+		return nil, err
+	}
+
+	// if provided we append.
+	if buf == nil {
+		buf = make([]int64, 0, m.count(l))
+	}
+
+	var sum int64
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		v, err := values.Sint64()
+		if err != nil {
+			return nil, err
+		}
+
+		sum += v
+		buf = append(buf, sum)
+	}
+
+	m.Index = values.Index
+	return buf, nil
+}
@@ -0,0 +1,232 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIterator_DeltaSint64(t *testing.T) {
+	values := []int64{100, 105, 90, -3000, -3000, 1 << 40}
+	message := &testmsg.Packed{
+		S32: deltas32([]int32{100, 105, 90, -3000, -3000, 1 << 30}),
+		S64: deltas64(values),
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data)
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 7:
+			iter, err := msg.Iterator(nil)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			var result []int32
+			for iter.HasNext() {
+				v, err := iter.DeltaSint32()
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				result = append(result, v)
+			}
+
+			expected := []int32{100, 105, 90, -3000, -3000, 1 << 30}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("incorrect values: %v", result)
+			}
+		case 8:
+			iter, err := msg.Iterator(nil)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			var result []int64
+			for iter.HasNext() {
+				v, err := iter.DeltaSint64()
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				result = append(result, v)
+			}
+
+			if !reflect.DeepEqual(result, values) {
+				t.Errorf("incorrect values: %v", result)
+			}
+
+			iter.Reset()
+			if v, err := iter.DeltaSint64(); err != nil || v != 100 {
+				t.Errorf("incorrect value after reset: %v %v", v, err)
+			}
+		default:
+			msg.Skip()
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{0x02, 0x80}}}
+		if v, err := iter.DeltaSint64(); err != nil || v != 1 {
+			t.Errorf("incorrect value: %v %v", v, err)
+		}
+
+		if _, err := iter.DeltaSint64(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+
+		if iter.delta != 1 {
+			t.Errorf("sum should not change on error: %v", iter.delta)
+		}
+	})
+}
+
+func TestMessage_RepeatedDeltaSint64(t *testing.T) {
+	values := []int64{100, 105, 90, -3000, -3000, 1 << 40}
+
+	t.Run("packed", func(t *testing.T) {
+		data, err := proto.Marshal(&testmsg.Packed{S64: deltas64(values)})
+		if err != nil {
+			t.Fatalf("unable to marshal: %v", err)
+		}
+
+		msg := New(data)
+		if !msg.Next() {
+			t.Fatalf("next is false?")
+		}
+
+		result, err := msg.RepeatedDeltaSint64([]int64{1})
+		if err != nil {
+			t.Fatalf("unable to read values: %v", err)
+		}
+
+		if !reflect.DeepEqual(result, append([]int64{1}, values...)) {
+			t.Errorf("incorrect values: %v", result)
+		}
+
+		if msg.Next() {
+			t.Errorf("should have read the whole field")
+		}
+	})
+
+	t.Run("unpacked", func(t *testing.T) {
+		data, err := proto.Marshal(&testmsg.Repeated{S64: []int64{5, -7}})
+		if err != nil {
+			t.Fatalf("unable to marshal: %v", err)
+		}
+
+		var result []int64
+		msg := New(data)
+		for msg.Next() {
+			result, err = msg.RepeatedDeltaSint64(result)
+			if err != nil {
+				t.Fatalf("unable to read values: %v", err)
+			}
+		}
+
+		if !reflect.DeepEqual(result, []int64{5, -7}) {
+			t.Errorf("incorrect values: %v", result)
+		}
+	})
+}
+
+func deltas64(values []int64) []int64 {
+	result := make([]int64, len(values))
+	var prev int64
+	for i, v := range values {
+		result[i] = v - prev
+		prev = v
+	}
+
+	return result
+}
+
+func deltas32(values []int32) []int32 {
+	result := make([]int32, len(values))
+	var prev int32
+	for i, v := range values {
+		result[i] = v - prev
+		prev = v
+	}
+
+	return result
+}
+
+func TestIterator_DeltaSint64_seek(t *testing.T) {
+	data, err := proto.Marshal(&testmsg.Packed{
+		S32: []int32{10, 20, 30, 40},
+		S64: []int64{10, 20, 30, 40},
+	})
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data)
+	for msg.Next() {
+		iter, err := msg.Iterator(nil)
+		if err != nil {
+			t.Fatalf("unable to create iterator: %v", err)
+		}
+
+		read := func() int64 {
+			t.Helper()
+			if msg.FieldNumber() == 7 {
+				v, err := iter.DeltaSint32()
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				return int64(v)
+			}
+
+			v, err := iter.DeltaSint64()
+			if err != nil {
+				t.Fatalf("unable to read value: %v", err)
+			}
+			return v
+		}
+
+		if err := iter.Seek(WireTypeVarint, 2); err != nil {
+			t.Fatalf("unable to seek: %v", err)
+		}
+
+		if v := read(); v != 60 {
+			t.Errorf("incorrect value after seek: %v", v)
+		}
+
+		if err := iter.Seek(WireTypeVarint, 1); err != nil {
+			t.Fatalf("unable to seek: %v", err)
+		}
+
+		if v := read(); v != 30 {
+			t.Errorf("incorrect value after seeking back: %v", v)
+		}
+
+		if err := iter.SkipN(WireTypeVarint, 1); err != nil {
+			t.Fatalf("unable to skip: %v", err)
+		}
+
+		if v := read(); v != 100 {
+			t.Errorf("incorrect value after skip: %v", v)
+		}
+
+		iter.Reset()
+		if v := read(); v != 10 {
+			t.Errorf("incorrect value after reset: %v", v)
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package protoscan
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIntOverflow is returned when scanning an integer with varint encoding and the
+// value is too long for the integer type.
+var ErrIntOverflow = errors.New("protoscan: integer overflow")
+
+// ErrInvalidLength is returned when a length is not valid, usually resulting
+// from scanning the incorrect type.
+var ErrInvalidLength = errors.New("protoscan: invalid length")
+
+// ErrInvalidGroup is returned when a group is closed by an end group tag
+// with a different field number than the start group tag, or when an end
+// group tag is found outside of a group.
+var ErrInvalidGroup = errors.New("protoscan: invalid group")
+
+// ErrInvalidTag is returned when a field tag has an invalid field number,
+// outside the range 1 to 2^29-1, or an unknown wire type.
+var ErrInvalidTag = errors.New("protoscan: invalid tag")
+
+// A TagError describes an invalid field tag.
+// It matches ErrInvalidTag when using errors.Is.
+type TagError struct {
+	FieldNumber int
+	WireType    int
+}
+
+// Error returns a message with the field number and wire type of the tag.
+func (e *TagError) Error() string {
+	return fmt.Sprintf("%v: field number %d, wire type %s",
+		ErrInvalidTag, e.FieldNumber, wireTypeName(e.WireType))
+}
+
+// Is returns true if the target is ErrInvalidTag.
+func (e *TagError) Is(target error) bool {
+	return target == ErrInvalidTag
+}
+
+// ErrInvalidWireType is returned when a wire type that can not be
+// used for packed repeated values is passed to an Iterator method.
+var ErrInvalidWireType = errors.New("protoscan: invalid wire type for packed values")
+
+// ErrNotFound is returned by FindPath if the field path is not in the message.
+var ErrNotFound = errors.New("protoscan: field not found")
+
+// ErrLimitExceeded is returned when the data exceeds one of the Limits.
+var ErrLimitExceeded = errors.New("protoscan: limit exceeded")
+
+// A LimitError describes which of the Limits was exceeded.
+// It matches ErrLimitExceeded when using errors.Is.
+type LimitError struct {
+	// Limit is "depth", "fields" or "length".
+	Limit string
+	Max   int
+	Value int
+}
+
+// Error returns a message with the limit and the value that exceeded it.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v: %s %d > %d", ErrLimitExceeded, e.Limit, e.Value, e.Max)
+}
+
+// Is returns true if the target is ErrLimitExceeded.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// ErrWireTypeMismatch is returned, in strict mode, when a value is read using
+// an accessor that does not match the wire type of the field.
+var ErrWireTypeMismatch = errors.New("protoscan: wire type mismatch")
+
+// A WireTypeError describes a wire type mismatch found in strict mode.
+// It matches ErrWireTypeMismatch when using errors.Is.
+type WireTypeError struct {
+	Expected int
+	Found    int
+}
+
+// Error returns a message with the expected and found wire types.
+func (e *WireTypeError) Error() string {
+	return fmt.Sprintf("%v: expected %s, found %s",
+		ErrWireTypeMismatch, wireTypeName(e.Expected), wireTypeName(e.Found))
+}
+
+// Is returns true if the target is ErrWireTypeMismatch.
+func (e *WireTypeError) Is(target error) bool {
+	return target == ErrWireTypeMismatch
+}
+
+// A DecodeError is returned when the data can not be scanned. It wraps one of
+// the errors above, or io.ErrUnexpectedEOF, so errors.Is can be used to check
+// the cause. The other values describe where in the data the error occurred.
+type DecodeError struct {
+	// Index is the position in the Message or Iterator data
+	// of the value that could not be read.
+	Index int
+
+	// FieldNumber and WireType are for the field being read.
+	// They are zero if the field tag could not be read.
+	FieldNumber int
+	WireType    int
+
+	// Path is the field numbers of the parent messages and groups,
+	// outermost first, when scanning an embedded message.
+	Path []int
+
+	Err error
+}
+
+// Error returns the underlying error with the location information.
+func (e *DecodeError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("%v: index %d, field %d, wire type %d",
+			e.Err, e.Index, e.FieldNumber, e.WireType)
+	}
+
+	return fmt.Sprintf("%v: index %d, field %d, wire type %d, path %v",
+		e.Err, e.Index, e.FieldNumber, e.WireType, e.Path)
+}
+
+// Unwrap returns the underlying error so it can be matched with errors.Is.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (b *base) decodeError(err error) error {
+	var path []int
+	if len(b.path) > 0 {
+		path = append(path, b.path...)
+	}
+
+	return &DecodeError{
+		Index:       b.Index,
+		FieldNumber: b.fieldNumber,
+		WireType:    b.wireType,
+		Path:        path,
+		Err:         err,
+	}
+}
+
+func wireTypeName(wireType int) string {
+	switch wireType {
+	case WireTypeVarint:
+		return "varint"
+	case WireType64bit:
+		return "64bit"
+	case WireTypeLengthDelimited:
+		return "length delimited"
+	case WireTypeStartGroup:
+		return "start group"
+	case WireTypeEndGroup:
+		return "end group"
+	case WireType32bit:
+		return "32bit"
+	}
+
+	return fmt.Sprintf("invalid(%d)", wireType)
+}
@@ -0,0 +1,104 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestDecodeError(t *testing.T) {
+	grandchild := protowire.AppendTag(nil, 1000, WireTypeVarint)
+	grandchild = append(grandchild, 0x80) // varint is not terminated
+
+	child := protowire.AppendTag(nil, 100, WireTypeVarint)
+	child = protowire.AppendVarint(child, 123)
+	child = protowire.AppendTag(child, 200, WireTypeLengthDelimited)
+	child = protowire.AppendBytes(child, grandchild)
+
+	data := protowire.AppendTag(nil, 1, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, child)
+
+	msg := New(data)
+	if !msg.Next() {
+		t.Fatalf("next is false?")
+	}
+
+	cmsg, err := msg.Message(nil)
+	if err != nil {
+		t.Fatalf("unable to read message: %v", err)
+	}
+
+	var gcmsg *Message
+	for cmsg.Next() {
+		switch cmsg.FieldNumber() {
+		case 200:
+			gcmsg, err = cmsg.Message(gcmsg)
+			if err != nil {
+				t.Fatalf("unable to read message: %v", err)
+			}
+		default:
+			cmsg.Skip()
+		}
+	}
+
+	if !gcmsg.Next() {
+		t.Fatalf("next is false?")
+	}
+
+	_, err = gcmsg.Int64()
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("incorrect error: %v", err)
+	}
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("should be a decode error: %T", err)
+	}
+
+	if derr.Index != 2 {
+		t.Errorf("incorrect index: %v", derr.Index)
+	}
+
+	if derr.FieldNumber != 1000 {
+		t.Errorf("incorrect field number: %v", derr.FieldNumber)
+	}
+
+	if derr.WireType != WireTypeVarint {
+		t.Errorf("incorrect wire type: %v", derr.WireType)
+	}
+
+	if len(derr.Path) != 2 || derr.Path[0] != 1 || derr.Path[1] != 200 {
+		t.Errorf("incorrect path: %v", derr.Path)
+	}
+
+	expected := "unexpected EOF: index 2, field 1000, wire type 0, path [1 200]"
+	if v := err.Error(); v != expected {
+		t.Errorf("incorrect message: %v", v)
+	}
+}
+
+func TestDecodeError_next(t *testing.T) {
+	msg := New([]byte{0x08, 0x01, 0x80})
+	for msg.Next() {
+		msg.Skip()
+	}
+
+	var derr *DecodeError
+	if !errors.As(msg.Err(), &derr) {
+		t.Fatalf("should be a decode error: %v", msg.Err())
+	}
+
+	if derr.Index != 2 {
+		t.Errorf("incorrect index: %v", derr.Index)
+	}
+
+	if derr.FieldNumber != 0 {
+		t.Errorf("field number should be zero if tag not read: %v", derr.FieldNumber)
+	}
+
+	if derr.Path != nil {
+		t.Errorf("path should be empty for top level message: %v", derr.Path)
+	}
+}
@@ -8,12 +8,6 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-type Field struct {
-	Number   int
-	WireType int
-	Data     []byte
-}
-
 // ReadIntoArray demonstrates how to get the raw data for each field in the message.
 func Example_readIntoArray() {
 	child := &testmsg.Child{
@@ -37,19 +31,15 @@ func Example_readIntoArray() {
 		panic(err)
 	}
 
-	fields := []Field{}
+	fields := []protoscan.Field{}
 
 	msg := protoscan.New(data)
 	for msg.Next() {
-		f := Field{
-			Number:   msg.FieldNumber(),
-			WireType: msg.WireType(),
+		f, err := msg.RawField()
+		if err != nil {
+			panic(err)
 		}
-		start := msg.Index
-		msg.Skip()
-		end := msg.Index
 
-		f.Data = msg.Data[start:end]
 		fields = append(fields, f)
 	}
 
@@ -58,16 +48,16 @@ func Example_readIntoArray() {
 	}
 
 	// Output:
-	// {Number:100 WireType:0 Data:[123]}
-	// {Number:200 WireType:2 Data:[50 192 62 111 130 125 44 255 255 255 255 255 255 255 255 255 1 2 253 255 255 255 255 255 255 255 255 1 4 251 255 255 255 255 255 255 255 255 1 6 249 255 255 255 255 255 255 255 255 1 8]}
-	// {Number:200 WireType:2 Data:[59 192 62 162 254 255 255 255 255 255 255 255 1 130 125 44 1 254 255 255 255 255 255 255 255 255 1 3 252 255 255 255 255 255 255 255 255 1 5 250 255 255 255 255 255 255 255 255 1 7 248 255 255 255 255 255 255 255 255 1]}
-	// {Number:300 WireType:0 Data:[1]}
-	// {Number:300 WireType:0 Data:[2]}
-	// {Number:300 WireType:0 Data:[3]}
-	// {Number:300 WireType:0 Data:[252 255 255 255 255 255 255 255 255 1]}
-	// {Number:300 WireType:0 Data:[251 255 255 255 255 255 255 255 255 1]}
-	// {Number:300 WireType:0 Data:[250 255 255 255 255 255 255 255 255 1]}
-	// {Number:300 WireType:0 Data:[7]}
-	// {Number:300 WireType:0 Data:[8]}
-	// {Number:3200 WireType:0 Data:[1]}
+	// {Number:100 WireType:0 Tag:[160 6] Value:[123]}
+	// {Number:200 WireType:2 Tag:[194 12] Value:[50 192 62 111 130 125 44 255 255 255 255 255 255 255 255 255 1 2 253 255 255 255 255 255 255 255 255 1 4 251 255 255 255 255 255 255 255 255 1 6 249 255 255 255 255 255 255 255 255 1 8]}
+	// {Number:200 WireType:2 Tag:[194 12] Value:[59 192 62 162 254 255 255 255 255 255 255 255 1 130 125 44 1 254 255 255 255 255 255 255 255 255 1 3 252 255 255 255 255 255 255 255 255 1 5 250 255 255 255 255 255 255 255 255 1 7 248 255 255 255 255 255 255 255 255 1]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[1]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[2]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[3]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[252 255 255 255 255 255 255 255 255 1]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[251 255 255 255 255 255 255 255 255 1]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[250 255 255 255 255 255 255 255 255 1]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[7]}
+	// {Number:300 WireType:0 Tag:[224 18] Value:[8]}
+	// {Number:3200 WireType:0 Tag:[128 200 1] Value:[1]}
 }
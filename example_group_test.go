@@ -25,17 +25,15 @@ func Example_groups() {
 	msg := New(data)
 	for msg.Next() {
 		if msg.FieldNumber() == groupFieldNum && msg.WireType() == WireTypeStartGroup {
-			start := msg.Index
-			end := msg.Index
-			for msg.Next() {
-				msg.Skip()
-				if msg.FieldNumber() == groupFieldNum && msg.WireType() == WireTypeEndGroup {
-					break
-				}
-				end = msg.Index
+			group, err := msg.Group(nil)
+			if err != nil {
+				panic(err)
 			}
+
 			// groupData would be the raw protobuf encoded bytes of the fields in the group.
-			groupData = msg.Data[start:end]
+			groupData = group.Data
+		} else {
+			msg.Skip()
 		}
 	}
 
@@ -65,17 +63,15 @@ func Example_emptyGroup() {
 	msg := New(data)
 	for msg.Next() {
 		if msg.FieldNumber() == groupFieldNum && msg.WireType() == WireTypeStartGroup {
-			start := msg.Index
-			end := msg.Index
-			for msg.Next() {
-				msg.Skip()
-				if msg.FieldNumber() == groupFieldNum && msg.WireType() == WireTypeEndGroup {
-					break
-				}
-				end = msg.Index
+			group, err := msg.Group(nil)
+			if err != nil {
+				panic(err)
 			}
+
 			// groupData would be the raw protobuf encoded bytes of the fields in the group.
-			groupData = msg.Data[start:end]
+			groupData = group.Data
+		} else {
+			msg.Skip()
 		}
 	}
 
@@ -0,0 +1,77 @@
+package protoscan
+
+// Find will move the scanner to the next field with the given field number,
+// skipping all the other fields. Like Next, the value of the current field must
+// be read or skipped before calling. Returns false if the field is not found
+// or there was an error, check Err() to tell the difference.
+//
+//	if msg.Find(3) {
+//	  v, err := msg.Int64()
+//	}
+func (m *Message) Find(fieldNumber int) bool {
+	for m.Next() {
+		if m.fieldNumber == fieldNumber {
+			return true
+		}
+		m.Skip()
+	}
+
+	return false
+}
+
+// FindPath returns a Message positioned on the field at the end of the path
+// of field numbers. The fields before the last one must be embedded messages
+// or groups. For example, FindPath(data, 3, 1) will find field 1 in the
+// embedded message of field 3. If there are multiple embedded messages for a
+// field number the first one containing the rest of the path is used.
+// Returns ErrNotFound if the path is not in the data.
+func FindPath(data []byte, path ...int) (*Message, error) {
+	if len(path) == 0 {
+		return nil, ErrNotFound
+	}
+
+	msg, err := findPath(New(data), path)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg == nil {
+		return nil, ErrNotFound
+	}
+
+	return msg, nil
+}
+
+func findPath(m *Message, path []int) (*Message, error) {
+	for m.Find(path[0]) {
+		if len(path) == 1 {
+			return m, nil
+		}
+
+		var embedded *Message
+		var err error
+		switch m.wireType {
+		case WireTypeStartGroup:
+			embedded, err = m.Group(nil)
+		case WireTypeLengthDelimited:
+			embedded, err = m.Message(nil)
+		default:
+			// a scalar value can not contain the rest of the path.
+			m.Skip()
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// a length delimited field may be a string or bytes that does not
+		// decode as a message, then the path may be in a later occurrence.
+		found, err := findPath(embedded, path[1:])
+		if err == nil && found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, m.Err()
+}
@@ -0,0 +1,202 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessage_Find(t *testing.T) {
+	message := &testmsg.Scalar{
+		Flt:   proto.Float32(1.5),
+		I64:   proto.Int64(123),
+		Str:   proto.String("abc"),
+		After: proto.Bool(true),
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data)
+	if !msg.Find(4) {
+		t.Fatalf("field not found: %v", msg.Err())
+	}
+
+	if v, err := msg.Int64(); err != nil || v != 123 {
+		t.Errorf("incorrect value: %v %v", v, err)
+	}
+
+	if !msg.Find(32) {
+		t.Fatalf("field not found: %v", msg.Err())
+	}
+
+	if v, err := msg.Bool(); err != nil || !v {
+		t.Errorf("incorrect value: %v %v", v, err)
+	}
+
+	msg.Reset(nil)
+	if msg.Find(5) {
+		t.Errorf("should not find missing field")
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Errorf("should not be an error: %v", err)
+	}
+}
+
+func TestFindPath(t *testing.T) {
+	c := &testmsg.Customer{
+		Id: proto.Int64(123),
+		Orders: []*testmsg.Order{
+			{
+				Id:   proto.Int64(1),
+				Open: proto.Bool(true),
+			},
+			{
+				Id:   proto.Int64(2),
+				Open: proto.Bool(false),
+				Items: []*testmsg.Item{
+					{Id: proto.Int64(20)},
+				},
+			},
+		},
+	}
+
+	data, err := proto.Marshal(c)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		path     []int
+		expected int64
+	}{
+		{
+			name:     "top level",
+			path:     []int{1},
+			expected: 123,
+		},
+		{
+			name:     "embedded",
+			path:     []int{3, 1},
+			expected: 1,
+		},
+		{
+			name:     "second embedded message",
+			path:     []int{3, 3, 1},
+			expected: 20,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := FindPath(data, tc.path...)
+			if err != nil {
+				t.Fatalf("unable to find: %v", err)
+			}
+
+			if v := msg.FieldNumber(); v != tc.path[len(tc.path)-1] {
+				t.Errorf("incorrect field number: %v", v)
+			}
+
+			v, err := msg.Int64()
+			if err != nil {
+				t.Fatalf("unable to read: %v", err)
+			}
+
+			if v != tc.expected {
+				t.Errorf("incorrect value: %v != %v", v, tc.expected)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		for _, path := range [][]int{{}, {2}, {3, 4}, {3, 3, 2}} {
+			_, err := FindPath(data, path...)
+			if err != ErrNotFound {
+				t.Errorf("incorrect error for %v: %v", path, err)
+			}
+		}
+	})
+
+	t.Run("group", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeStartGroup)
+		data = protowire.AppendTag(data, 2, WireTypeVarint)
+		data = protowire.AppendVarint(data, 100)
+		data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+
+		msg, err := FindPath(data, 1, 2)
+		if err != nil {
+			t.Fatalf("unable to find: %v", err)
+		}
+
+		if v, err := msg.Int64(); err != nil || v != 100 {
+			t.Errorf("incorrect value: %v %v", v, err)
+		}
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		_, err := FindPath(data[:len(data)-1], 3, 3, 2)
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func TestFindPath_scalar(t *testing.T) {
+	// field 1 is a varint so it can not contain field 2
+	data := []byte{0x08, 0x02, 0x10, 0x05}
+	if _, err := FindPath(data, 1, 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	// a later occurrence of the field is an embedded message
+	embedded := protowire.AppendTag(nil, 2, WireTypeVarint)
+	embedded = protowire.AppendVarint(embedded, 7)
+	data = protowire.AppendTag(data, 1, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, embedded)
+
+	msg, err := FindPath(data, 1, 2)
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+
+	if v, err := msg.Int64(); err != nil || v != 7 {
+		t.Errorf("incorrect value: %v %v", v, err)
+	}
+}
+
+func TestFindPath_notMessage(t *testing.T) {
+	// field 2 is a string that does not decode as a message
+	data := protowire.AppendTag(nil, 2, WireTypeLengthDelimited)
+	data = protowire.AppendString(data, "abc")
+
+	if _, err := FindPath(data, 2, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	// field 3 is bytes followed by a valid embedded message
+	data = protowire.AppendTag(nil, 3, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, []byte{0xff, 0xff})
+
+	embedded := protowire.AppendTag(nil, 1, WireTypeVarint)
+	embedded = protowire.AppendVarint(embedded, 7)
+	data = protowire.AppendTag(data, 3, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, embedded)
+
+	msg, err := FindPath(data, 3, 1)
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+
+	if v, err := msg.Int64(); err != nil || v != 7 {
+		t.Errorf("incorrect value: %v %v", v, err)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/paulmach/protoscan
 
-go 1.15
+go 1.23
 
 require google.golang.org/protobuf v1.33.0
@@ -0,0 +1,122 @@
+package protoscan
+
+// A FieldIndex records the location of all the top level fields in a message
+// so they can be read in any order without rescanning the data.
+type FieldIndex struct {
+	data   []byte
+	opts   []Option
+	fields map[int][]fieldRange
+}
+
+// fieldRange is the wire type and location of the tag and value of a field.
+type fieldRange struct {
+	wireType int
+	tag      int
+	start    int
+	end      int
+}
+
+// BuildIndex scans the message data once and returns an index of the fields.
+// The options are used for the scan and by the Messages and Iterators
+// returned by the index.
+func BuildIndex(data []byte, opts ...Option) (*FieldIndex, error) {
+	idx := &FieldIndex{
+		data:   data,
+		opts:   opts,
+		fields: make(map[int][]fieldRange),
+	}
+
+	msg := New(data, opts...)
+	for msg.Next() {
+		start := msg.Index
+		msg.Skip()
+		if msg.err != nil {
+			break
+		}
+
+		idx.fields[msg.fieldNumber] = append(idx.fields[msg.fieldNumber], fieldRange{
+			wireType: msg.wireType,
+			tag:      msg.tagIndex,
+			start:    start,
+			end:      msg.Index,
+		})
+	}
+
+	if err := msg.Err(); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// Count returns the number of times the field is in the message.
+// Packed repeated values count as one.
+func (idx *FieldIndex) Count(fieldNumber int) int {
+	return len(idx.fields[fieldNumber])
+}
+
+// Last returns a Message positioned on the last occurrence of the field,
+// ready for the value to be read. This is the value to use for non-repeated
+// fields. Returns nil if the field is not in the message.
+func (idx *FieldIndex) Last(fieldNumber int) *Message {
+	fields := idx.fields[fieldNumber]
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return idx.message(fieldNumber, fields[len(fields)-1])
+}
+
+// All returns a Message positioned on each occurrence of the field.
+func (idx *FieldIndex) All(fieldNumber int) []*Message {
+	fields := idx.fields[fieldNumber]
+	if len(fields) == 0 {
+		return nil
+	}
+
+	result := make([]*Message, 0, len(fields))
+	for _, f := range fields {
+		result = append(result, idx.message(fieldNumber, f))
+	}
+
+	return result
+}
+
+// Message returns the embedded message, or group, for the i-th occurrence
+// of the field. Returns ErrNotFound if there are not that many occurrences.
+func (idx *FieldIndex) Message(fieldNumber, i int) (*Message, error) {
+	fields := idx.fields[fieldNumber]
+	if i < 0 || len(fields) <= i {
+		return nil, ErrNotFound
+	}
+
+	m := idx.message(fieldNumber, fields[i])
+	if m.wireType == WireTypeStartGroup {
+		return m.Group(nil)
+	}
+
+	return m.Message(nil)
+}
+
+// Iterator returns an iterator for the i-th occurrence of a packed repeated
+// field. Returns ErrNotFound if there are not that many occurrences.
+func (idx *FieldIndex) Iterator(fieldNumber, i int) (*Iterator, error) {
+	fields := idx.fields[fieldNumber]
+	if i < 0 || len(fields) <= i {
+		return nil, ErrNotFound
+	}
+
+	return idx.message(fieldNumber, fields[i]).Iterator(nil)
+}
+
+// message returns a Message positioned at the start of the field's value.
+// The data ends with the field so calling Next after reading will return false.
+func (idx *FieldIndex) message(fieldNumber int, f fieldRange) *Message {
+	m := New(idx.data[:f.end], idx.opts...)
+	m.Index = f.start
+	m.tagIndex = f.tag
+	m.fieldNumber = fieldNumber
+	m.wireType = f.wireType
+
+	return m
+}
@@ -0,0 +1,151 @@
+package protoscan
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBuildIndex(t *testing.T) {
+	c := &testmsg.Customer{
+		Id:       proto.Int64(123),
+		Username: proto.String("name"),
+		Orders: []*testmsg.Order{
+			{Id: proto.Int64(1), Open: proto.Bool(true)},
+			{Id: proto.Int64(2), Open: proto.Bool(false)},
+			{Id: proto.Int64(3), Open: proto.Bool(true)},
+		},
+		FavoriteIds: []int64{1, 2, 3, 4, 5},
+	}
+
+	data, err := proto.Marshal(c)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	idx, err := BuildIndex(data)
+	if err != nil {
+		t.Fatalf("unable to build index: %v", err)
+	}
+
+	// read in any order, more than once
+	for i := 0; i < 2; i++ {
+		username, err := idx.Last(2).String()
+		if err != nil || username != "name" {
+			t.Errorf("incorrect username: %v %v", username, err)
+		}
+
+		id, err := idx.Last(1).Int64()
+		if err != nil || id != 123 {
+			t.Errorf("incorrect id: %v %v", id, err)
+		}
+	}
+
+	if v := idx.Count(3); v != 3 {
+		t.Errorf("incorrect count: %v", v)
+	}
+
+	order, err := idx.Message(3, 1)
+	if err != nil {
+		t.Fatalf("unable to get message: %v", err)
+	}
+
+	if !order.Find(1) {
+		t.Fatalf("order id not found")
+	}
+
+	if v, err := order.Int64(); err != nil || v != 2 {
+		t.Errorf("incorrect order id: %v %v", v, err)
+	}
+
+	if _, err := idx.Message(3, 3); err != ErrNotFound {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	var orderIDs []int64
+	for _, m := range idx.All(3) {
+		order, err := m.Message(nil)
+		if err != nil {
+			t.Fatalf("unable to read order: %v", err)
+		}
+
+		if order.Find(1) {
+			v, _ := order.Int64()
+			orderIDs = append(orderIDs, v)
+		}
+	}
+
+	if len(orderIDs) != 3 || orderIDs[0] != 1 || orderIDs[2] != 3 {
+		t.Errorf("incorrect order ids: %v", orderIDs)
+	}
+
+	iter, err := idx.Iterator(4, 0)
+	if err != nil {
+		t.Fatalf("unable to get iterator: %v", err)
+	}
+
+	if v := iter.Count(WireTypeVarint); v != 5 {
+		t.Errorf("incorrect count: %v", v)
+	}
+
+	if idx.Last(5) != nil {
+		t.Errorf("missing field should be nil")
+	}
+
+	if idx.All(5) != nil {
+		t.Errorf("missing field should be nil")
+	}
+
+	t.Run("message is limited to the field", func(t *testing.T) {
+		m := idx.Last(1)
+		m.Skip()
+
+		if m.Next() {
+			t.Errorf("should not read past the field")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := BuildIndex(data[:len(data)-1])
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func TestFieldIndex_RawField(t *testing.T) {
+	data := []byte{0x08, 0x05, 0x12, 0x02, 'a', 'b', 0x18, 0x09}
+
+	idx, err := BuildIndex(data)
+	if err != nil {
+		t.Fatalf("unable to build index: %v", err)
+	}
+
+	f, err := idx.Last(3).RawField()
+	if err != nil {
+		t.Fatalf("unable to read field: %v", err)
+	}
+
+	if !bytes.Equal(f.Tag, []byte{0x18}) || !bytes.Equal(f.Value, []byte{0x09}) {
+		t.Errorf("incorrect field: %v", f)
+	}
+
+	w := NewWriter(nil)
+	for _, m := range idx.All(2) {
+		if err := w.CopyField(m); err != nil {
+			t.Fatalf("unable to copy field: %v", err)
+		}
+	}
+
+	if err := w.CopyField(idx.Last(1)); err != nil {
+		t.Fatalf("unable to copy field: %v", err)
+	}
+
+	if !bytes.Equal(w.Data, []byte{0x12, 0x02, 'a', 'b', 0x08, 0x05}) {
+		t.Errorf("incorrect data: %v", w.Data)
+	}
+}
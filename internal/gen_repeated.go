@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
 const tmpl = `
@@ -18,6 +19,10 @@ func (m *Message) Repeated%[1]s(buf []%[2]s) ([]%[2]s, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -28,20 +33,297 @@ func (m *Message) Repeated%[1]s(buf []%[2]s) ([]%[2]s, error) {
 		buf = make([]%[2]s, 0, %[4]s)
 	}
 
-	postIndex := m.index + l
-	for m.index < postIndex {
-		v, err := m.%[1]s()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, %[3]s)
+	for values.Index < len(values.Data) {
+%[5]s
+		buf = append(buf, %[6]s)
+	}
+
+	m.Index = values.Index
+	return buf, nil
+}
+`
+
+const iteratorTmpl = `
+// %[1]sIterator reads the values of a packed repeated %[4]s field.
+type %[1]sIterator struct {
+	iter Iterator
+	err  error
+}
+
+// %[1]sIterator returns an iterator for the current packed repeated field
+// with %[4]s values. Will reuse the provided iterator if provided.
+func (m *Message) %[1]sIterator(iter *%[1]sIterator) (*%[1]sIterator, error) {
+	if iter == nil {
+		iter = &%[1]sIterator{}
+	}
+
+	if _, err := m.Iterator(&iter.iter); err != nil {
+		return nil, err
+	}
+
+	iter.err = nil
+	return iter, nil
+}
+
+// Next returns the next value. Returns false if there are no more
+// values or there was an error, check Err() to tell the difference.
+func (i *%[1]sIterator) Next() (%[2]s, bool) {
+	if i.err != nil || !i.iter.HasNext() {
+		return %[5]s, false
+	}
+
+	v, err := i.iter.%[1]s()
+	if err != nil {
+		i.err = err
+		return %[5]s, false
+	}
+
+	return v, true
+}
+
+// Len returns the number of values left to read.
+func (i *%[1]sIterator) Len() int {
+	return i.iter.remaining(%[3]s)
+}
+
+// Skip moves the iterator forward n values without reading them.
+func (i *%[1]sIterator) Skip(n int) error {
+	return i.iter.SkipN(%[3]s, n)
+}
+
+// Seek moves the iterator so the next value read is the n-th value, starting at 0.
+// See Iterator.Seek for more information.
+func (i *%[1]sIterator) Seek(n int) error {
+	return i.iter.Seek(%[3]s, n)
+}
+
+// Reset moves the iterator back to the first value.
+func (i *%[1]sIterator) Reset() {
+	i.iter.Reset()
+	i.err = nil
+}
+
+// Err returns the error, if any, encountered by Next.
+func (i *%[1]sIterator) Err() error {
+	return i.err
+}
+
+// FieldNumber returns the number for the repeated field.
+func (i *%[1]sIterator) FieldNumber() int {
+	return i.iter.FieldNumber()
+}
+`
+
+const seqTmpl = `
+// %[1]ss returns a sequence over the remaining values of the packed repeated
+// field, decoded as %[4]s, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) %[1]ss() iter.Seq2[%[2]s, error] {
+	return func(yield func(%[2]s, error) bool) {
+		for i.HasNext() {
+			v, err := i.%[1]s()
+			if err != nil {
+				yield(%[5]s, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+`
+
+const readVarintTmpl = `
+// Read%[1]ss reads up to len(dst) %[4]s values into dst and returns the number
+// of values read. It has io.Reader semantics: once all the values have been read
+// it returns 0, io.EOF. If a value can not be decoded the values before it and
+// the error are returned.
+func (i *Iterator) Read%[1]ss(dst []%[2]s) (int, error) {
+	if err := i.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
+
+	if len(dst) == 0 {
+		return 0, nil
+	}
+
+	if i.Index >= len(i.Data) {
+		return 0, io.EOF
+	}
+
+	n := 0
+	data := i.Data
+	index := i.Index
+	for j := range dst {
+		if uint(index) >= uint(len(data)) {
+			break
+		}
+
+		if d := data[index]; d < 0x80 {
+			v := uint64(d)
+			dst[j] = %[5]s
+			index++
+			n++
+			continue
+		}
+
+		next, v, err := %[6]s(data, index)
 		if err != nil {
-			return nil, err
+			i.Index = index
+			return n, i.decodeError(err)
 		}
 
-		buf = append(buf, v)
+		dst[j] = %[5]s
+		index = next
+		n++
 	}
 
-	return buf, nil
+	i.Index = index
+	return n, nil
+}
+`
+
+const readFixedTmpl = `
+// Read%[1]ss reads up to len(dst) %[4]s values into dst and returns the number
+// of values read. It has io.Reader semantics: once all the values have been read
+// it returns 0, io.EOF. If the last value is truncated the values before it are
+// returned, the next call returns io.ErrUnexpectedEOF.
+func (i *Iterator) Read%[1]ss(dst []%[2]s) (int, error) {
+	if err := i.checkWireType(%[3]s); err != nil {
+		return 0, err
+	}
+
+	if len(dst) == 0 {
+		return 0, nil
+	}
+
+	if i.Index >= len(i.Data) {
+		return 0, io.EOF
+	}
+
+	n := (len(i.Data) - i.Index) / %[6]d
+	if n == 0 {
+		return 0, i.decodeError(io.ErrUnexpectedEOF)
+	}
+
+	if n > len(dst) {
+		n = len(dst)
+	}
+
+	// slicing once here removes the bounds checks from the loop.
+	data := i.Data[i.Index : i.Index+%[6]d*n]
+	for j := range dst[:n] {
+		v := binary.LittleEndian.Uint%[7]d(data)
+		dst[j] = %[5]s
+		data = data[%[6]d:]
+	}
+
+	i.Index += %[6]d * n
+	return n, nil
+}
+`
+
+const decodeVarint = `		next, v, err := %s(values.Data, values.Index)
+		if err != nil {
+			return nil, values.decodeError(err)
+		}
+
+		values.Index = next`
+
+const decodeFixed = `		if len(values.Data) < values.Index+%[1]d {
+			return nil, values.decodeError(io.ErrUnexpectedEOF)
+		}
+
+		v := binary.LittleEndian.Uint%[2]d(values.Data[values.Index:])
+		values.Index += %[1]d`
+
+// readValues are the expressions to convert the raw value v.
+var readValues = map[string]string{
+	"Float":    "math.Float32frombits(v)",
+	"Double":   "math.Float64frombits(v)",
+	"Int32":    "int32(v)",
+	"Int64":    "int64(v)",
+	"Uint32":   "uint32(v)",
+	"Uint64":   "v",
+	"Sint32":   "int32(unZig64(v))",
+	"Sint64":   "unZig64(v)",
+	"Fixed32":  "v",
+	"Fixed64":  "v",
+	"Sfixed32": "int32(v)",
+	"Sfixed64": "int64(v)",
+	"Bool":     "v == 1",
+}
+
+const packedVarintTmpl = `
+// Packed%[1]s writes the values as a packed repeated %[4]s field.
+// Nothing is written if there are no values.
+func (w *Writer) Packed%[1]s(field int, values []%[2]s) {
+	if len(values) == 0 {
+		return
+	}
+
+	l := 0
+	for _, v := range values {
+		l += varintSize(%[5]s)
+	}
+
+	w.tag(field, WireTypeLengthDelimited)
+	w.Data = appendVarint(w.Data, uint64(l))
+	for _, v := range values {
+		w.Data = appendVarint(w.Data, %[5]s)
+	}
+}
+
+// %[1]s appends a %[4]s value to the packed field.
+func (p *PackedWriter) %[1]s(v %[2]s) {
+	p.w.Data = appendVarint(p.w.Data, %[5]s)
 }
 `
 
+const packedFixedTmpl = `
+// Packed%[1]s writes the values as a packed repeated %[4]s field.
+// Nothing is written if there are no values.
+func (w *Writer) Packed%[1]s(field int, values []%[2]s) {
+	if len(values) == 0 {
+		return
+	}
+
+	w.tag(field, WireTypeLengthDelimited)
+	w.Data = appendVarint(w.Data, uint64(%[6]d*len(values)))
+	for _, v := range values {
+		w.Data = binary.LittleEndian.AppendUint%[7]d(w.Data, %[5]s)
+	}
+}
+
+// %[1]s appends a %[4]s value to the packed field.
+func (p *PackedWriter) %[1]s(v %[2]s) {
+	p.w.Data = binary.LittleEndian.AppendUint%[7]d(p.w.Data, %[5]s)
+}
+`
+
+// writeValues are the expressions to convert the value v to its
+// unsigned encoded form.
+var writeValues = map[string]string{
+	"Float":    "math.Float32bits(v)",
+	"Double":   "math.Float64bits(v)",
+	"Int32":    "uint64(int64(v))",
+	"Int64":    "uint64(v)",
+	"Uint32":   "uint64(v)",
+	"Uint64":   "v",
+	"Sint32":   "zig64(int64(v))",
+	"Sint64":   "zig64(v)",
+	"Fixed32":  "v",
+	"Fixed64":  "v",
+	"Sfixed32": "uint32(v)",
+	"Sfixed64": "uint64(v)",
+	"Bool":     "boolVarint(v)",
+}
+
 var types = [][]string{
 	{"Float", "float32", "WireType32bit", "l/4"},
 	{"Double", "float64", "WireType64bit", "l/8"},
@@ -64,10 +346,105 @@ func main() {
 		panic(err)
 	}
 
+	fmt.Fprintf(f, "// Code generated by internal/gen_repeated.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(f, "package protoscan\n\n")
+	fmt.Fprintf(f, "import (\n\t\"encoding/binary\"\n\t\"io\"\n\t\"math\"\n)\n")
+
+	for _, t := range types {
+		var decode string
+		switch t[2] {
+		case "WireTypeVarint":
+			decode = fmt.Sprintf(decodeVarint, "varint64")
+			if t[0] == "Uint32" {
+				decode = fmt.Sprintf(decodeVarint, "varint32")
+			}
+		case "WireType32bit":
+			decode = fmt.Sprintf(decodeFixed, 4, 32)
+		case "WireType64bit":
+			decode = fmt.Sprintf(decodeFixed, 8, 64)
+		}
+
+		fmt.Fprintf(f, tmpl, t[0], t[1], t[2], t[3], decode, readValues[t[0]])
+	}
+
+	f, err = os.Create("typed_iterator.go")
+	if err != nil {
+		panic(err)
+	}
+
 	fmt.Fprintf(f, "// Code generated by internal/gen_repeated.go. DO NOT EDIT.\n\n")
 	fmt.Fprintf(f, "package protoscan\n")
 
 	for _, t := range types {
-		fmt.Fprintf(f, tmpl, t[0], t[1], t[2], t[3])
+		zero := "0"
+		if t[1] == "bool" {
+			zero = "false"
+		}
+
+		fmt.Fprintf(f, iteratorTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), zero)
+	}
+
+	f, err = os.Create("typed_seq.go")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Fprintf(f, "// Code generated by internal/gen_repeated.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(f, "package protoscan\n\n")
+	fmt.Fprintf(f, "import \"iter\"\n")
+
+	for _, t := range types {
+		zero := "0"
+		if t[1] == "bool" {
+			zero = "false"
+		}
+
+		fmt.Fprintf(f, seqTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), zero)
+	}
+
+	f, err = os.Create("iterator_read.go")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Fprintf(f, "// Code generated by internal/gen_repeated.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(f, "package protoscan\n\n")
+	fmt.Fprintf(f, "import (\n\t\"encoding/binary\"\n\t\"io\"\n\t\"math\"\n)\n")
+
+	for _, t := range types {
+		switch t[2] {
+		case "WireTypeVarint":
+			// match the accessor, only uint32 values are limited to 32 bits.
+			varint := "varint64"
+			if t[0] == "Uint32" {
+				varint = "varint32"
+			}
+
+			fmt.Fprintf(f, readVarintTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), readValues[t[0]], varint)
+		case "WireType32bit":
+			fmt.Fprintf(f, readFixedTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), readValues[t[0]], 4, 32)
+		case "WireType64bit":
+			fmt.Fprintf(f, readFixedTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), readValues[t[0]], 8, 64)
+		}
+	}
+
+	f, err = os.Create("writer_packed.go")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Fprintf(f, "// Code generated by internal/gen_repeated.go. DO NOT EDIT.\n\n")
+	fmt.Fprintf(f, "package protoscan\n\n")
+	fmt.Fprintf(f, "import (\n\t\"encoding/binary\"\n\t\"math\"\n)\n")
+
+	for _, t := range types {
+		switch t[2] {
+		case "WireTypeVarint":
+			fmt.Fprintf(f, packedVarintTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), writeValues[t[0]])
+		case "WireType32bit":
+			fmt.Fprintf(f, packedFixedTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), writeValues[t[0]], 4, 32)
+		case "WireType64bit":
+			fmt.Fprintf(f, packedFixedTmpl, t[0], t[1], t[2], strings.ToLower(t[0]), writeValues[t[0]], 8, 64)
+		}
 	}
 }
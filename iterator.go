@@ -1,17 +1,43 @@
 package protoscan
 
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// wireTypePacked is the wire type of an Iterator in strict mode
+// before the first value is read.
+const wireTypePacked = -1
+
+// checkpointInterval is the number of varint values between
+// the checkpoints used by Iterator.Seek.
+const checkpointInterval = 64
+
 // An Iterator allows for moving across a packed repeated field
 // in a 'controlled' fashion.
 type Iterator struct {
 	base
 
-	fieldNumber int
+	// checkpoints are the index of every checkpointInterval-th varint value.
+	// They are built by the first varint Seek.
+	checkpoints []int
+	varints     int
+	indexed     bool
+
+	// delta is the running sum of the DeltaSint64 and DeltaSint32 values
+	// before deltaIndex.
+	delta      int64
+	deltaIndex int
 }
 
 // Iterator will use the current field. The field must be a packed
-// repeated field.
+// repeated field. In strict mode the first value read sets the wire type,
+// reading the other values as a different type will return an error.
 func (m *Message) Iterator(iter *Iterator) (*Iterator, error) {
-	// TODO: validate wiretype makes sense
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
 
 	l, err := m.packedLength()
 	if err != nil {
@@ -22,10 +48,21 @@ func (m *Message) Iterator(iter *Iterator) (*Iterator, error) {
 		iter = &Iterator{}
 	}
 	iter.base = base{
-		Data:  m.Data[m.Index : m.Index+l],
-		Index: 0,
+		Data:        m.Data[m.Index : m.Index+l],
+		Index:       0,
+		fieldNumber: m.fieldNumber,
+		wireType:    m.wireType,
+		path:        m.path,
+		strict:      m.strict,
+	}
+	if m.strict {
+		iter.wireType = wireTypePacked
 	}
-	iter.fieldNumber = m.fieldNumber
+	iter.checkpoints = iter.checkpoints[:0]
+	iter.varints = 0
+	iter.indexed = false
+	iter.delta = 0
+	iter.deltaIndex = 0
 	m.Index += l
 
 	return iter, nil
@@ -44,51 +81,246 @@ func (i *Iterator) HasNext() bool {
 // pointer so the next value call with be the 'counth' value.
 // double, float, fixed, sfixed are WireType32bit or WireType64bit,
 // all others int, uint, sint types are WireTypeVarint.
-// The function will panic for any other value.
+// The function will panic for any other value, use SkipN to return an error instead.
+// The panic is kept for backwards compatibility, it only happens if the caller
+// passes an invalid wire type and never because of the data.
+// If there are fewer than 'count' values left the iterator is moved to the end.
 func (i *Iterator) Skip(wireType int, count int) {
-	if wireType == WireTypeVarint {
-		for j := 0; j < count; j++ {
-			for i.Data[i.Index] >= 128 {
-				i.Index++
+	err := i.SkipN(wireType, count)
+	if errors.Is(err, ErrInvalidWireType) {
+		panic("invalid wire type for a packed repeated field")
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// HasNext will be false and reading a value will return io.ErrUnexpectedEOF.
+		i.Index = len(i.Data)
+	}
+}
+
+// SkipN will move the iterator forward n values without reading them.
+// The wireType must be WireTypeVarint, WireType32bit or WireType64bit, see Skip.
+// Returns io.ErrUnexpectedEOF if there are fewer than n values left, the
+// iterator is not moved in this case. A negative n does not move the iterator.
+func (i *Iterator) SkipN(wireType int, n int) error {
+	if err := i.checkPackedWireType(wireType); err != nil {
+		return err
+	}
+
+	if n <= 0 {
+		return nil
+	}
+
+	switch wireType {
+	case WireTypeVarint:
+		index := i.Index
+		for j := 0; j < n; j++ {
+			for index < len(i.Data) && i.Data[index] >= 128 {
+				index++
+			}
+
+			if index >= len(i.Data) {
+				return i.decodeError(io.ErrUnexpectedEOF)
+			}
+			index++
+		}
+
+		i.Index = index
+	case WireType32bit:
+		if (len(i.Data)-i.Index)/4 < n {
+			return i.decodeError(io.ErrUnexpectedEOF)
+		}
+		i.Index += 4 * n
+	case WireType64bit:
+		if (len(i.Data)-i.Index)/8 < n {
+			return i.decodeError(io.ErrUnexpectedEOF)
+		}
+		i.Index += 8 * n
+	}
+
+	return nil
+}
+
+// Seek moves the iterator so the next value read is the n-th value, starting at 0.
+// The wireType must be WireTypeVarint, WireType32bit or WireType64bit, see Skip.
+// For fixed size values this is a simple calculation. For varint values the first
+// call will scan the data and build a table of checkpoints so seeks after that only
+// need to scan from the nearest checkpoint. Returns io.ErrUnexpectedEOF if n is
+// greater than the number of values, the iterator is not moved in this case.
+func (i *Iterator) Seek(wireType int, n int) error {
+	if err := i.checkPackedWireType(wireType); err != nil {
+		return err
+	}
+
+	switch wireType {
+	case WireTypeVarint:
+		if !i.indexed {
+			i.buildCheckpoints()
+		}
+
+		if n < 0 || i.varints < n {
+			return i.decodeError(io.ErrUnexpectedEOF)
+		}
+
+		if n == i.varints {
+			i.Index = len(i.Data)
+			return nil
+		}
+
+		index := i.checkpoints[n/checkpointInterval]
+		for j := 0; j < n%checkpointInterval; j++ {
+			// all values were validated when building the checkpoints.
+			for i.Data[index] >= 128 {
+				index++
 			}
-			i.Index++
+			index++
+		}
+
+		i.Index = index
+	case WireType32bit:
+		if n < 0 || len(i.Data)/4 < n {
+			return i.decodeError(io.ErrUnexpectedEOF)
+		}
+		i.Index = 4 * n
+	case WireType64bit:
+		if n < 0 || len(i.Data)/8 < n {
+			return i.decodeError(io.ErrUnexpectedEOF)
 		}
-		return
-	} else if wireType == WireType32bit {
-		i.Index += 4 * count
-		return
-	} else if wireType == WireType64bit {
-		i.Index += 8 * count
-		return
+		i.Index = 8 * n
 	}
 
-	panic("invalid wire type for a packed repeated field")
+	return nil
+}
+
+// Reset moves the iterator back to the first value.
+func (i *Iterator) Reset() {
+	i.Index = 0
+}
+
+// buildCheckpoints records the index of every checkpointInterval-th
+// varint value and the total number of complete varint values.
+func (i *Iterator) buildCheckpoints() {
+	i.checkpoints = i.checkpoints[:0]
+	i.varints = 0
+
+	index := 0
+	for index < len(i.Data) {
+		if i.varints%checkpointInterval == 0 {
+			i.checkpoints = append(i.checkpoints, index)
+		}
+
+		for index < len(i.Data) && i.Data[index] >= 128 {
+			index++
+		}
+
+		if index == len(i.Data) {
+			break // the last value is truncated
+		}
+
+		index++
+		i.varints++
+	}
+
+	i.indexed = true
 }
 
 // Count returns the total number of values in this repeated field.
 // The answer depends on the type/encoding or the field:
 // double, float, fixed, sfixed are WireType32bit or WireType64bit,
 // all others int, uint, sint types are WireTypeVarint.
-// The function will panic for any other value.
+// The function will panic for any other value, use CountE to return an error instead.
+// Like Skip, the panic is kept for backwards compatibility and only happens
+// if the caller passes an invalid wire type.
 func (i *Iterator) Count(wireType int) int {
-	if wireType == WireTypeVarint {
-		var count int
-		for _, b := range i.Data {
-			if b < 128 {
-				count++
-			}
+	count, err := i.CountE(wireType)
+	if errors.Is(err, ErrInvalidWireType) {
+		panic("invalid wire type for a packed repeated field")
+	}
+
+	return count
+}
+
+// CountE returns the total number of values in this repeated field.
+// The wireType must be WireTypeVarint, WireType32bit or WireType64bit, see Count.
+// Returns io.ErrUnexpectedEOF, and the number of complete values,
+// if the last value is truncated.
+func (i *Iterator) CountE(wireType int) (int, error) {
+	if err := i.checkPackedWireType(wireType); err != nil {
+		return 0, err
+	}
+
+	switch wireType {
+	case WireTypeVarint:
+		count := countVarints(i.Data)
+		if len(i.Data) > 0 && i.Data[len(i.Data)-1] >= 128 {
+			return count, i.decodeError(io.ErrUnexpectedEOF)
+		}
+		return count, nil
+	case WireType32bit:
+		if len(i.Data)%4 != 0 {
+			return len(i.Data) / 4, i.decodeError(io.ErrUnexpectedEOF)
+		}
+		return len(i.Data) / 4, nil
+	default: // WireType64bit
+		if len(i.Data)%8 != 0 {
+			return len(i.Data) / 8, i.decodeError(io.ErrUnexpectedEOF)
+		}
+		return len(i.Data) / 8, nil
+	}
+}
+
+// remaining returns the number of values after the current index.
+func (i *Iterator) remaining(wireType int) int {
+	if i.Index >= len(i.Data) {
+		return 0
+	}
+
+	switch wireType {
+	case WireTypeVarint:
+		return countVarints(i.Data[i.Index:])
+	case WireType32bit:
+		return (len(i.Data) - i.Index) / 4
+	case WireType64bit:
+		return (len(i.Data) - i.Index) / 8
+	}
+
+	return 0
+}
+
+// countVarints returns the number of varint values in the data by counting
+// the last byte of each value, the bytes with the high bit not set.
+func countVarints(data []byte) int {
+	var count int
+	for len(data) >= 8 {
+		// eight bytes at a time: move the inverted high bits to the low bit
+		// of each byte and add them up per byte. A byte can hold the sum
+		// of 255 words before the bytes are added together with the multiply.
+		var sums uint64
+		for j := 0; j < 255 && len(data) >= 8; j++ {
+			w := binary.LittleEndian.Uint64(data)
+			sums += (^w & 0x8080808080808080) >> 7
+			data = data[8:]
 		}
 
-		return count
+		count += int((((sums & 0x00ff00ff00ff00ff) + ((sums >> 8) & 0x00ff00ff00ff00ff)) * 0x0001000100010001) >> 48)
 	}
-	if wireType == WireType32bit {
-		return len(i.base.Data) / 4
+
+	for _, b := range data {
+		if b < 128 {
+			count++
+		}
 	}
-	if wireType == WireType64bit {
-		return len(i.base.Data) / 8
+
+	return count
+}
+
+// checkPackedWireType returns an error if the wire type can not be packed,
+// or in strict mode, it does not match the values read so far.
+func (i *Iterator) checkPackedWireType(wireType int) error {
+	if wireType != WireTypeVarint && wireType != WireType32bit && wireType != WireType64bit {
+		return i.decodeError(ErrInvalidWireType)
 	}
 
-	panic("invalid wire type for a packed repeated field")
+	return i.checkWireType(wireType)
 }
 
 // FieldNumber returns the number for the current repeated field.
@@ -0,0 +1,207 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIterator_Read(t *testing.T) {
+	message := &testmsg.Packed{
+		Flt:  []float32{1, 2, 3, 4, 5, 6, 7},
+		Dbl:  []float64{1.5, 2.5, 3.5, 4.5},
+		I32:  []int32{-1, 2, 300, 4000, 5},
+		I64:  []int64{1, -2, 3 << 40, 4, 5, 6, 7, 8},
+		U32:  []uint32{1, 2, 1 << 30},
+		U64:  []uint64{1 << 60, 2, 3, 4},
+		S32:  []int32{-1, 2, -300},
+		S64:  []int64{-1, 2, -3 << 40, 4, -5},
+		F32:  []uint32{10, 20, 30, 40},
+		F64:  []uint64{10, 20, 30},
+		Sf32: []int32{-10, 20, -30},
+		Sf64: []int64{-10, 20, -30, 40, -50},
+		Bool: []bool{true, false, true, true},
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	result := &testmsg.Packed{}
+
+	msg := New(data)
+	for msg.Next() {
+		iter, err := msg.Iterator(nil)
+		if err != nil {
+			t.Fatalf("unable to create iterator: %v", err)
+		}
+
+		switch msg.FieldNumber() {
+		case 1:
+			result.Flt = readAll(t, iter.ReadFloats)
+		case 2:
+			result.Dbl = readAll(t, iter.ReadDoubles)
+		case 3:
+			result.I32 = readAll(t, iter.ReadInt32s)
+		case 4:
+			result.I64 = readAll(t, iter.ReadInt64s)
+		case 5:
+			result.U32 = readAll(t, iter.ReadUint32s)
+		case 6:
+			result.U64 = readAll(t, iter.ReadUint64s)
+		case 7:
+			result.S32 = readAll(t, iter.ReadSint32s)
+		case 8:
+			result.S64 = readAll(t, iter.ReadSint64s)
+		case 9:
+			result.F32 = readAll(t, iter.ReadFixed32s)
+		case 10:
+			result.F64 = readAll(t, iter.ReadFixed64s)
+		case 11:
+			result.Sf32 = readAll(t, iter.ReadSfixed32s)
+		case 12:
+			result.Sf64 = readAll(t, iter.ReadSfixed64s)
+		case 13:
+			result.Bool = readAll(t, iter.ReadBools)
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	compare(t, result, message)
+}
+
+func TestIterator_Read_errors(t *testing.T) {
+	t.Run("empty dst", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 2}}}
+		if n, err := iter.ReadInt64s(nil); n != 0 || err != nil {
+			t.Errorf("incorrect result: %v %v", n, err)
+		}
+
+		if iter.Index != 0 {
+			t.Errorf("should not move: %v", iter.Index)
+		}
+	})
+
+	t.Run("truncated varint", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 2, 0x80}}}
+
+		dst := make([]int64, 10)
+		n, err := iter.ReadInt64s(dst)
+		if n != 2 || dst[0] != 1 || dst[1] != 2 {
+			t.Errorf("incorrect values: %v %v", n, dst[:n])
+		}
+
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+
+		if iter.Index != 2 {
+			t.Errorf("should point at the invalid value: %v", iter.Index)
+		}
+	})
+
+	t.Run("uint32 overflow", func(t *testing.T) {
+		data := []byte{1, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}
+
+		iter := &Iterator{base: base{Data: data}}
+		iter.Index = 1
+		_, expected := iter.Uint32()
+
+		iter.Reset()
+		dst := make([]uint32, 10)
+		n, err := iter.ReadUint32s(dst)
+		if n != 1 || dst[0] != 1 {
+			t.Errorf("incorrect values: %v %v", n, dst[:n])
+		}
+
+		if !errors.Is(err, ErrIntOverflow) || !errors.Is(expected, ErrIntOverflow) {
+			t.Errorf("should match the accessor: %v %v", err, expected)
+		}
+	})
+
+	t.Run("truncated fixed", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 0, 0, 0, 2, 0}}}
+
+		dst := make([]uint32, 10)
+		n, err := iter.ReadFixed32s(dst)
+		if n != 1 || err != nil || dst[0] != 1 {
+			t.Errorf("incorrect result: %v %v %v", n, err, dst[:n])
+		}
+
+		n, err = iter.ReadFixed32s(dst)
+		if n != 0 || !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect result: %v %v", n, err)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 0, 0, 0}, strict: true, wireType: wireTypePacked}}
+		if _, err := iter.ReadFixed32s(make([]uint32, 1)); err != nil {
+			t.Fatalf("unable to read: %v", err)
+		}
+
+		iter.Reset()
+		if _, err := iter.ReadInt64s(make([]int64, 1)); !errors.Is(err, ErrWireTypeMismatch) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func readAll[T any](t testing.TB, read func([]T) (int, error)) []T {
+	t.Helper()
+
+	var result []T
+	buf := make([]T, 3)
+	for {
+		n, err := read(buf)
+		result = append(result, buf[:n]...)
+		if err == io.EOF {
+			return result
+		}
+
+		if err != nil {
+			t.Fatalf("unable to read: %v", err)
+		}
+	}
+}
+
+func BenchmarkIterator_ReadInt64s(b *testing.B) {
+	message := &testmsg.Packed{I64: make([]int64, 10000)}
+	for i := range message.I64 {
+		message.I64[i] = int64(i * i)
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		b.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data)
+	msg.Next()
+
+	iter, err := msg.Iterator(nil)
+	if err != nil {
+		b.Fatalf("unable to create iterator: %v", err)
+	}
+
+	buf := make([]int64, 256)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iter.Reset()
+		for {
+			_, err := iter.ReadInt64s(buf)
+			if err == io.EOF {
+				break
+			}
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package protoscan
 
 import (
+	"errors"
 	"io"
 	"testing"
 
 	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -201,7 +203,7 @@ func TestIterator_errors(t *testing.T) {
 	}
 
 	_, err = msg.Iterator(nil)
-	if err != io.ErrUnexpectedEOF {
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
 		t.Fatalf("incorrect error: %v", err)
 	}
 }
@@ -322,6 +324,376 @@ func TestIterator_Skip(t *testing.T) {
 	}
 }
 
+func TestIterator_SkipN(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		wireType int
+		n        int
+		index    int
+		err      error
+	}{
+		{
+			name:     "varint",
+			data:     []byte{1, 200, 1, 3},
+			wireType: WireTypeVarint,
+			n:        2,
+			index:    3,
+		},
+		{
+			name:     "varint all",
+			data:     []byte{1, 200, 1, 3},
+			wireType: WireTypeVarint,
+			n:        3,
+			index:    4,
+		},
+		{
+			name:     "varint too many",
+			data:     []byte{1, 200, 1, 3},
+			wireType: WireTypeVarint,
+			n:        4,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "truncated varint",
+			data:     []byte{1, 200, 201},
+			wireType: WireTypeVarint,
+			n:        2,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "32bit",
+			data:     make([]byte, 12),
+			wireType: WireType32bit,
+			n:        3,
+			index:    12,
+		},
+		{
+			name:     "32bit too many",
+			data:     make([]byte, 12),
+			wireType: WireType32bit,
+			n:        4,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "64bit",
+			data:     make([]byte, 16),
+			wireType: WireType64bit,
+			n:        1,
+			index:    8,
+		},
+		{
+			name:     "64bit too many",
+			data:     make([]byte, 15),
+			wireType: WireType64bit,
+			n:        2,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "negative",
+			data:     make([]byte, 16),
+			wireType: WireType64bit,
+			n:        -1,
+			index:    0,
+		},
+		{
+			name:     "invalid wire type",
+			data:     make([]byte, 16),
+			wireType: WireTypeLengthDelimited,
+			n:        1,
+			err:      ErrInvalidWireType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			iter := &Iterator{base: base{Data: tc.data}}
+
+			err := iter.SkipN(tc.wireType, tc.n)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("incorrect error: %v", err)
+				}
+
+				if iter.Index != 0 {
+					t.Errorf("should not move on error: %v", iter.Index)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if iter.Index != tc.index {
+				t.Errorf("incorrect index: %v != %v", iter.Index, tc.index)
+			}
+		})
+	}
+
+	t.Run("skip does not panic", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 200, 201}}}
+		iter.Skip(WireTypeVarint, 2)
+
+		if iter.HasNext() {
+			t.Errorf("should be at the end")
+		}
+
+		if _, err := iter.Int64(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func TestIterator_Seek(t *testing.T) {
+	message := &testmsg.Packed{
+		I64: make([]int64, 1000),
+		F32: make([]uint32, 1000),
+	}
+
+	for i := range message.I64 {
+		message.I64[i] = int64(i * i * i) // variable length
+		message.F32[i] = uint32(i)
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data)
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 4:
+			iter, err := msg.Iterator(nil)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			for _, n := range []int{500, 0, 63, 64, 65, 999, 128, 1} {
+				if err := iter.Seek(WireTypeVarint, n); err != nil {
+					t.Fatalf("unable to seek to %d: %v", n, err)
+				}
+
+				if v, err := iter.Int64(); err != nil || v != int64(n*n*n) {
+					t.Errorf("incorrect value at %d: %v %v", n, v, err)
+				}
+			}
+
+			if err := iter.Seek(WireTypeVarint, 1000); err != nil {
+				t.Fatalf("unable to seek to the end: %v", err)
+			}
+
+			if iter.HasNext() {
+				t.Errorf("should be at the end")
+			}
+
+			index := iter.Index
+			if err := iter.Seek(WireTypeVarint, 1001); !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("incorrect error: %v", err)
+			}
+
+			if iter.Index != index {
+				t.Errorf("should not move on error")
+			}
+
+			iter.Reset()
+			if v, err := iter.Int64(); err != nil || v != 0 {
+				t.Errorf("incorrect value after reset: %v %v", v, err)
+			}
+		case 9:
+			iter, err := msg.Iterator(nil)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			for _, n := range []int{500, 0, 999, 1} {
+				if err := iter.Seek(WireType32bit, n); err != nil {
+					t.Fatalf("unable to seek to %d: %v", n, err)
+				}
+
+				if v, err := iter.Fixed32(); err != nil || v != uint32(n) {
+					t.Errorf("incorrect value at %d: %v %v", n, v, err)
+				}
+			}
+
+			if err := iter.Seek(WireType32bit, 1001); !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("incorrect error: %v", err)
+			}
+		default:
+			msg.Skip()
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	t.Run("reused iterator", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 2, 3}}}
+		if err := iter.Seek(WireTypeVarint, 2); err != nil {
+			t.Fatalf("unable to seek: %v", err)
+		}
+
+		msg := New(protowire.AppendBytes(protowire.AppendTag(nil, 1, WireTypeLengthDelimited), []byte{4, 5}))
+		msg.Next()
+
+		iter, err := msg.Iterator(iter)
+		if err != nil {
+			t.Fatalf("unable to create iterator: %v", err)
+		}
+
+		if err := iter.Seek(WireTypeVarint, 2); err != nil {
+			t.Fatalf("unable to seek: %v", err)
+		}
+
+		if iter.HasNext() {
+			t.Errorf("checkpoints should be rebuilt for new data")
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 2, 200}}}
+		if err := iter.Seek(WireTypeVarint, 3); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func TestCountVarints(t *testing.T) {
+	var data []byte
+	for i := 0; i < 100; i++ {
+		data = protowire.AppendVarint(data, uint64(i*i*i*i))
+	}
+
+	for i := 0; i <= len(data); i++ {
+		var expected int
+		for _, b := range data[i:] {
+			if b < 128 {
+				expected++
+			}
+		}
+
+		if v := countVarints(data[i:]); v != expected {
+			t.Errorf("incorrect count from %d: %v != %v", i, v, expected)
+		}
+	}
+}
+
+func TestIterator_CountE(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		wireType int
+		count    int
+		err      error
+	}{
+		{
+			name:     "varint",
+			data:     []byte{1, 200, 1, 3},
+			wireType: WireTypeVarint,
+			count:    3,
+		},
+		{
+			name:     "truncated varint",
+			data:     []byte{1, 200, 1, 200},
+			wireType: WireTypeVarint,
+			count:    2,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "32bit",
+			data:     make([]byte, 12),
+			wireType: WireType32bit,
+			count:    3,
+		},
+		{
+			name:     "truncated 32bit",
+			data:     make([]byte, 13),
+			wireType: WireType32bit,
+			count:    3,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "64bit",
+			data:     make([]byte, 16),
+			wireType: WireType64bit,
+			count:    2,
+		},
+		{
+			name:     "truncated 64bit",
+			data:     make([]byte, 15),
+			wireType: WireType64bit,
+			count:    1,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "invalid wire type",
+			data:     make([]byte, 16),
+			wireType: WireTypeStartGroup,
+			err:      ErrInvalidWireType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			iter := &Iterator{base: base{Data: tc.data}}
+
+			count, err := iter.CountE(tc.wireType)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("incorrect error: %v", err)
+			}
+
+			if count != tc.count {
+				t.Errorf("incorrect count: %v != %v", count, tc.count)
+			}
+		})
+	}
+}
+
+func TestIterator_strictWireTypes(t *testing.T) {
+	message := &testmsg.Packed{
+		I64: []int64{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data, StrictWireTypes())
+	if !msg.Next() {
+		t.Fatalf("next is false?")
+	}
+
+	iter, err := msg.Iterator(nil)
+	if err != nil {
+		t.Fatalf("error getting iterator: %v", err)
+	}
+
+	if v, err := iter.Int64(); err != nil || v != 1 {
+		t.Fatalf("incorrect value: %v %v", v, err)
+	}
+
+	if v, err := iter.Sint64(); err != nil || v != 1 {
+		t.Fatalf("same wire type should be okay: %v %v", v, err)
+	}
+
+	_, err = iter.Fixed32()
+	if !errors.Is(err, ErrWireTypeMismatch) {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	// a wire type mismatch should not move the iterator to the end
+	iter.Skip(WireType32bit, 1)
+	if !iter.HasNext() {
+		t.Fatalf("should not move to the end")
+	}
+
+	if v, err := iter.Int64(); err != nil || v != 3 {
+		t.Errorf("incorrect value: %v %v", v, err)
+	}
+}
+
 func TestIterator_FieldNumber(t *testing.T) {
 	message := &testmsg.Packed{
 		I64: make([]int64, 4000),
@@ -719,3 +1091,58 @@ func BenchmarkIterateSkip_all(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkIteratorCount_varint(b *testing.B) {
+	data := packedIDs(b, 100000)
+
+	msg := New(data)
+	msg.Next()
+
+	iter, err := msg.Iterator(nil)
+	if err != nil {
+		b.Fatalf("unable to create iterator: %v", err)
+	}
+
+	b.SetBytes(int64(len(iter.Data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if c := iter.Count(WireTypeVarint); c != 100000 {
+			b.Fatalf("incorrect count: %v", c)
+		}
+	}
+}
+
+func BenchmarkIteratorCount_bytewise(b *testing.B) {
+	data := packedIDs(b, 100000)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// the previous byte at a time implementation, for comparison.
+		var count int
+		for _, c := range data {
+			if c < 128 {
+				count++
+			}
+		}
+		_ = count
+	}
+}
+
+func packedIDs(b *testing.B, n int) []byte {
+	items := make([]int64, n)
+	for i := range items {
+		items[i] = int64(1e9 + 1000*i)
+	}
+
+	data, err := proto.Marshal(&testmsg.Packed{I64: items})
+	if err != nil {
+		b.Fatalf("unable to marshal: %v", err)
+	}
+
+	return data
+}
@@ -0,0 +1,33 @@
+package protoscan
+
+// Limits restrict the resources used when scanning untrusted data.
+// A zero value means there is no limit.
+type Limits struct {
+	// MaxDepth is the maximum nesting depth of embedded messages
+	// and groups read using Message() or Group().
+	MaxDepth int
+
+	// MaxFields is the maximum number of fields returned by Next
+	// for one message.
+	MaxFields int
+
+	// MaxLength is the maximum size, in bytes, of a length delimited value
+	// that is read. Skipped values are not checked.
+	MaxLength int
+}
+
+// WithLimits will return an ErrLimitExceeded if the data exceeds any of the limits.
+// Embedded messages inherit the limits of the parent message.
+func WithLimits(limits Limits) Option {
+	return func(o *options) {
+		o.limits = limits
+	}
+}
+
+func (m *Message) checkDepth() error {
+	if m.limits.MaxDepth > 0 && m.depth >= m.limits.MaxDepth {
+		return m.decodeError(&LimitError{Limit: "depth", Max: m.limits.MaxDepth, Value: m.depth + 1})
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package protoscan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWithLimits(t *testing.T) {
+	parent := &testmsg.Parent{
+		Child: &testmsg.Child{
+			Number:  proto.Int64(123),
+			Numbers: []int64{1, 2, 3, 4, 5, 6, 7, 8},
+			Grandchild: []*testmsg.Grandchild{
+				{
+					Number:  proto.Int64(111),
+					Numbers: []int64{1, 2, 3, 4, 5, 6, 7, 8},
+				},
+			},
+		},
+		After: proto.Bool(true),
+	}
+
+	data, err := proto.Marshal(parent)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		limits Limits
+		err    string
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name:   "within limits",
+			limits: Limits{MaxDepth: 2, MaxFields: 10, MaxLength: 100},
+		},
+		{
+			name:   "depth",
+			limits: Limits{MaxDepth: 1},
+			err:    "depth",
+		},
+		{
+			name:   "fields",
+			limits: Limits{MaxFields: 5},
+			err:    "fields",
+		},
+		{
+			name:   "length",
+			limits: Limits{MaxLength: 20},
+			err:    "length",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := scanParent(New(data, WithLimits(tc.limits)))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Fatalf("incorrect error: %v", err)
+			}
+
+			var lerr *LimitError
+			if !errors.As(err, &lerr) {
+				t.Fatalf("should be a limit error: %v", err)
+			}
+
+			if lerr.Limit != tc.err {
+				t.Errorf("incorrect limit: %v", lerr.Limit)
+			}
+		})
+	}
+}
+
+// scanParent reads all the embedded messages and repeated values
+// in a testmsg.Parent returning the first error.
+func scanParent(msg *Message) error {
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 1, 200: // child, grandchild
+			embedded, err := msg.Message(nil)
+			if err != nil {
+				return err
+			}
+
+			if err := scanParent(embedded); err != nil {
+				return err
+			}
+		case 300, 2000: // numbers
+			if _, err := msg.RepeatedInt64(nil); err != nil {
+				return err
+			}
+		default:
+			msg.Skip()
+		}
+	}
+
+	return msg.Err()
+}
+
+func TestWithLimits_value(t *testing.T) {
+	parent := &testmsg.Parent{
+		Child: &testmsg.Child{
+			Grandchild: []*testmsg.Grandchild{
+				{Number: proto.Int64(111)},
+			},
+		},
+	}
+
+	data, err := proto.Marshal(parent)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data, WithLimits(Limits{MaxDepth: 1}), StrictWireTypes())
+	if !msg.Find(1) {
+		t.Fatalf("child not found: %v", msg.Err())
+	}
+
+	v, err := msg.Value()
+	if err != nil {
+		t.Fatalf("unable to read value: %v", err)
+	}
+
+	child := v.AsMessage()
+	if child.depth != 1 || child.limits.MaxDepth != 1 || !child.strict {
+		t.Errorf("should inherit the settings: %v %v %v", child.depth, child.limits, child.strict)
+	}
+
+	if !child.Find(200) {
+		t.Fatalf("grandchild not found: %v", child.Err())
+	}
+
+	v, err = child.Value()
+	if err != nil {
+		t.Fatalf("unable to read value: %v", err)
+	}
+
+	grandchild := v.AsMessage()
+	if grandchild.Next() {
+		t.Errorf("should not scan beyond the max depth")
+	}
+
+	err = grandchild.Err()
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("incorrect error: %v", err)
+	}
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) || len(derr.Path) != 2 || derr.Path[0] != 1 || derr.Path[1] != 200 {
+		t.Errorf("incorrect path: %v", err)
+	}
+
+	// options replace the inherited limits
+	if m := v.AsMessage(WithLimits(Limits{MaxDepth: 5})); !m.Next() {
+		t.Errorf("should scan with new limits: %v", m.Err())
+	}
+}
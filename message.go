@@ -1,36 +1,41 @@
 package protoscan
 
 import (
-	"errors"
 	"io"
 )
 
 //go:generate protoc --go_out=internal/testmsg internal/testmsg/types.proto
 //go:generate go run internal/gen_repeated.go
 
-// ErrIntOverflow is returned when scanning an integer with varint encoding and the
-// value is too long for the integer type.
-var ErrIntOverflow = errors.New("protoscan: integer overflow")
-
-// ErrInvalidLength is returned when a length is not valid, usually resulting
-// from scanning the incorrect type.
-var ErrInvalidLength = errors.New("protoscan: invalid length")
-
 // The WireType describes the encoding method for the next value in the stream.
 const (
 	WireTypeVarint          = 0
 	WireType64bit           = 1
 	WireTypeLengthDelimited = 2
-	WireTypeStartGroup      = 3 // deprecated by protobuf, not supported
-	WireTypeEndGroup        = 4 // deprecated by protobuf, not supported
+	WireTypeStartGroup      = 3 // groups and editions delimited messages, see Message.Group
+	WireTypeEndGroup        = 4
 	WireType32bit           = 5
 )
 
+// maxFieldNumber is the largest valid field number, 2^29-1.
+const maxFieldNumber = 1<<29 - 1
+
 // base has all the methods for reading packable fields (the numbers) so they
 // can be shared between message and iterator.
 type base struct {
 	Data  []byte
 	Index int
+
+	fieldNumber int
+	wireType    int
+
+	// path is the field numbers of the parent messages,
+	// it is only used to provide context for errors.
+	path []int
+
+	// strict will return an error if a value is read using an
+	// accessor that does not match the wire type.
+	strict bool
 }
 
 // Message is a container for a protobuf message type that is ready for scanning.
@@ -38,18 +43,66 @@ type Message struct {
 	base
 	err error
 
-	fieldNumber int
-	wireType    int
+	// tagIndex is the index of the current field's tag.
+	tagIndex int
+
+	limits     Limits
+	depth      int // of embedded messages, zero for the top level message
+	fieldCount int // read by Next
+}
+
+// A Field is the raw encoded data of a field, see Message.RawField.
+type Field struct {
+	Number   int
+	WireType int
+
+	// Tag is the encoded field number and wire type.
+	Tag []byte
+
+	// Value is the encoded value, including the length prefix of
+	// length delimited values and the end group tag of groups.
+	// Tag followed by Value is the complete field.
+	Value []byte
+}
+
+// An Option can be passed to New to configure the Message scanner.
+// Embedded messages read using Message() or Group() use the same options.
+type Option func(*options)
+
+type options struct {
+	strict bool
+	limits Limits
+}
+
+// StrictWireTypes will make all the value accessors check the wire type of the
+// current field and return an ErrWireTypeMismatch if it is not the expected one.
+// By default the data is read as the requested type regardless of the wire type.
+func StrictWireTypes() Option {
+	return func(o *options) {
+		o.strict = true
+	}
 }
 
 // New creates a new Message scanner for the given encoded protobuf data.
-func New(data []byte) *Message {
-	return &Message{
+func New(data []byte, opts ...Option) *Message {
+	m := &Message{
 		base: base{
 			Data:  data,
 			Index: 0,
 		},
 	}
+
+	if len(opts) > 0 {
+		m.setOptions(opts)
+	}
+
+	return m
+}
+
+// SetStrictWireTypes turns on or off the checking of wire types by the value accessors.
+// See the StrictWireTypes option for more information.
+func (m *Message) SetStrictWireTypes(strict bool) {
+	m.strict = strict
 }
 
 // Next will move the scanner to the next value. This function should be used in a for loop.
@@ -67,11 +120,32 @@ func (m *Message) Next() bool {
 		return false
 	}
 	if m.Index < len(m.Data) {
-		val, err := m.Varint64()
+		// the tag is validated here, like in readTag, so the call
+		// to varint64 can be inlined.
+		index, val, err := varint64(m.Data, m.Index)
+		if err == nil && (val>>3 == 0 || val>>3 > maxFieldNumber || val&0x7 > WireType32bit) {
+			err = &TagError{FieldNumber: int(val >> 3), WireType: int(val & 0x7)}
+		}
+
+		// groups are read or skipped including their end group tag,
+		// so an end group tag here has no matching start group tag.
+		if err == nil && val&0x7 == WireTypeEndGroup {
+			err = ErrInvalidGroup
+		}
+
+		if err == nil && m.limits.MaxFields > 0 && m.fieldCount >= m.limits.MaxFields {
+			err = &LimitError{Limit: "fields", Max: m.limits.MaxFields, Value: m.fieldCount + 1}
+		}
+
 		if err != nil {
-			m.err = err
+			m.fieldNumber = 0
+			m.wireType = 0
+			m.err = m.decodeError(err)
 			return false
 		}
+		m.fieldCount++
+		m.tagIndex = m.Index
+		m.Index = index
 		m.fieldNumber = int(val >> 3)
 		m.wireType = int(val & 0x7)
 		return true
@@ -80,8 +154,26 @@ func (m *Message) Next() bool {
 	return false
 }
 
+// Peek returns the field number and wire type of the next field without
+// moving the scanner or changing the current field. The value of the current
+// field must already be read or skipped. Returns false if there are no more
+// fields or the next tag can not be read, Next will then return the error.
+func (m *Message) Peek() (fieldNumber, wireType int, ok bool) {
+	if m.err != nil || m.Index >= len(m.Data) {
+		return 0, 0, false
+	}
+
+	_, fieldNumber, wireType, err := readTag(m.Data, m.Index)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return fieldNumber, wireType, true
+}
+
 // Err will return any errors that were encountered during scanning.
 // Errors could be due to reading the incorrect types or forgetting to skip and unused value.
+// Errors from decoding the data are of type *DecodeError.
 func (m *Message) Err() error {
 	return m.err
 }
@@ -99,66 +191,98 @@ func (m *Message) WireType() int {
 
 // Skip will move the scanner past the current value if it is not needed.
 // If a value is not parsed this method must be called to move the decoder past the value.
+// For a group this will skip the whole group, including any nested groups.
 func (m *Message) Skip() {
-	switch m.wireType {
-	case WireTypeVarint:
-		_, m.err = m.Varint64()
-	case WireType64bit:
-		if len(m.Data) <= m.Index+8 {
-			m.err = io.ErrUnexpectedEOF
-			return
-		}
-		m.Index += 8
-	case WireTypeLengthDelimited:
-		l, err := m.packedLength()
-		if err != nil {
-			m.err = err
-			return
-		}
-		m.Index += l
-	case WireType32bit:
-		if len(m.Data) <= m.Index+4 {
-			m.err = io.ErrUnexpectedEOF
-			return
-		}
-		m.Index += 4
+	index, err := skipValue(m.Data, m.Index, m.fieldNumber, m.wireType)
+	if err != nil {
+		m.err = m.decodeError(err)
+		return
 	}
+
+	m.Index = index
+}
+
+// RawField returns the encoded tag and value of the current field as sub
+// slices of the message data and moves the scanner past the field.
+// This can be used to copy fields, unknown or otherwise, without decoding them.
+// Like the other accessors this must be called right after Next.
+func (m *Message) RawField() (Field, error) {
+	index, err := skipValue(m.Data, m.Index, m.fieldNumber, m.wireType)
+	if err != nil {
+		return Field{}, m.decodeError(err)
+	}
+
+	f := Field{
+		Number:   m.fieldNumber,
+		WireType: m.wireType,
+		Tag:      m.Data[m.tagIndex:m.Index],
+		Value:    m.Data[m.Index:index],
+	}
+
+	m.Index = index
+	return f, nil
 }
 
 // Message will return a pointer to an embedded message that can then
 // be scanned in kind of a recursive fashion. Will reuse the provided
 // Message object if provided.
 func (m *Message) Message(msg *Message) (*Message, error) {
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
+	if err := m.checkDepth(); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
 	}
 
-	if msg == nil {
-		msg = New(m.Data[m.Index : m.Index+l])
-	} else {
-		msg.Reset(m.Data[m.Index : m.Index+l])
+	msg = m.embedded(msg, m.Data[m.Index:m.Index+l])
+	m.Index += l
+	return msg, nil
+}
+
+// Group will return a pointer to the fields of a group that can then be
+// scanned like an embedded message. Delimited message fields from protobuf
+// editions use the same encoding and can also be read with this method.
+// The scanner is moved past the matching end group tag. Will reuse the
+// provided Message object if provided.
+func (m *Message) Group(msg *Message) (*Message, error) {
+	if err := m.checkWireType(WireTypeStartGroup); err != nil {
+		return nil, err
 	}
 
-	m.Index += l
+	if err := m.checkDepth(); err != nil {
+		return nil, err
+	}
+
+	end, next, err := groupEnd(m.Data, m.Index, m.fieldNumber)
+	if err != nil {
+		return nil, m.decodeError(err)
+	}
+
+	msg = m.embedded(msg, m.Data[m.Index:end])
+	m.Index = next
 	return msg, nil
 }
 
 // MessageData returns the encoded data a message. This data can
 // then be decoded using conventional tools.
 func (m *Message) MessageData() ([]byte, error) {
-	l, err := m.packedLength()
-	if err != nil {
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
 		return nil, err
 	}
 
-	postIndex := m.Index + l
-	if len(m.Data) < postIndex {
-		return nil, io.ErrUnexpectedEOF
+	l, err := m.packedLength()
+	if err != nil {
+		return nil, err
 	}
 
-	d := m.Data[m.Index:postIndex]
-	m.Index = postIndex
+	d := m.Data[m.Index : m.Index+l]
+	m.Index += l
 	return d, nil
 }
 
@@ -167,46 +291,193 @@ func (m *Message) MessageData() ([]byte, error) {
 func (m *Message) Reset(newData []byte) {
 	if newData != nil {
 		m.Data = newData
+		m.path = m.path[:0]
 	}
 	m.err = nil
 	m.Index = 0
+	m.tagIndex = 0
+	m.fieldCount = 0
 	m.fieldNumber = 0
 	m.wireType = 0
 }
 
+// setOptions is not inlined so New can be, keeping
+// the Message on the stack if possible.
+//
+//go:noinline
+func (m *Message) setOptions(opts []Option) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	m.strict = o.strict
+	m.limits = o.limits
+}
+
+// embedded sets up the msg, or a new one if nil, to scan the data
+// of an embedded message or group in the current field.
+func (m *Message) embedded(msg *Message, data []byte) *Message {
+	if msg == nil {
+		msg = New(data)
+	} else {
+		msg.Reset(data)
+	}
+
+	msg.path = append(append(msg.path[:0], m.path...), m.fieldNumber)
+	msg.strict = m.strict
+	msg.limits = m.limits
+	msg.depth = m.depth + 1
+	return msg
+}
+
+// packedLength reads the length of a length delimited value and moves
+// the index to the start of the value.
 func (m *Message) packedLength() (int, error) {
-	var err error
-	var l64 uint64
-	m.Index, l64, err = varint64(m.Data, m.Index)
+	index, l64, err := varint64(m.Data, m.Index)
 	if err != nil {
-		return 0, err
+		return 0, m.decodeError(err)
 	}
 
 	l := int(l64)
 	if l < 0 {
-		return 0, ErrInvalidLength
+		return 0, m.decodeError(ErrInvalidLength)
 	}
 
-	postIndex := m.Index + l
+	postIndex := index + l
 	if postIndex < 0 {
 		// because there could be overflow...
-		return 0, ErrInvalidLength
+		return 0, m.decodeError(ErrInvalidLength)
 	}
 
 	if len(m.Data) < postIndex {
-		return 0, io.ErrUnexpectedEOF
+		return 0, m.decodeError(io.ErrUnexpectedEOF)
+	}
+
+	if m.limits.MaxLength > 0 && l > m.limits.MaxLength {
+		return 0, m.decodeError(&LimitError{Limit: "length", Max: m.limits.MaxLength, Value: l})
 	}
 
+	m.Index = index
 	return l, nil
 }
 
+// packed returns a base to read the packed values, with the given wire type,
+// in the next l bytes. Errors will have the index in the message data.
+func (m *Message) packed(l, wireType int) base {
+	b := m.base
+	b.Data = m.Data[:m.Index+l]
+	b.wireType = wireType
+	return b
+}
+
 func (m *Message) count(l int) int {
-	var count int
-	for _, b := range m.Data[m.Index : m.Index+l] {
-		if b < 128 {
-			count++
+	return countVarints(m.Data[m.Index : m.Index+l])
+}
+
+// skipValue returns the index after the value of the given wire type
+// that starts at index.
+func skipValue(data []byte, index, fieldNumber, wireType int) (int, error) {
+	switch wireType {
+	case WireTypeVarint:
+		i, _, err := varint64(data, index)
+		if err != nil {
+			return index, err
+		}
+		return i, nil
+	case WireType64bit:
+		if len(data) < index+8 {
+			return index, io.ErrUnexpectedEOF
+		}
+		return index + 8, nil
+	case WireTypeLengthDelimited:
+		i, l64, err := varint64(data, index)
+		if err != nil {
+			return index, err
+		}
+
+		l := int(l64)
+		if l < 0 || i+l < 0 {
+			return index, ErrInvalidLength
 		}
+
+		if len(data) < i+l {
+			return index, io.ErrUnexpectedEOF
+		}
+		return i + l, nil
+	case WireTypeStartGroup:
+		_, i, err := groupEnd(data, index, fieldNumber)
+		if err != nil {
+			return index, err
+		}
+		return i, nil
+	case WireType32bit:
+		if len(data) < index+4 {
+			return index, io.ErrUnexpectedEOF
+		}
+		return index + 4, nil
+	case WireTypeEndGroup:
+		// nothing to skip, the end of a group is just the tag.
+		return index, nil
 	}
 
-	return count
+	return index, &TagError{FieldNumber: fieldNumber, WireType: wireType}
+}
+
+// readTag reads and validates the field tag at index. It returns
+// the index after the tag, the field number and wire type.
+func readTag(data []byte, index int) (int, int, int, error) {
+	index, val, err := varint64(data, index)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	fieldNumber, wireType := val>>3, int(val&0x7)
+	if fieldNumber == 0 || fieldNumber > maxFieldNumber || wireType > WireType32bit {
+		return 0, 0, 0, &TagError{FieldNumber: int(fieldNumber), WireType: wireType}
+	}
+
+	return index, int(fieldNumber), wireType, nil
+}
+
+// groupEnd finds the end group tag matching a group with the given field number
+// whose contents start at index. It returns the index of the end group tag and
+// the index after it. Nested groups are skipped.
+func groupEnd(data []byte, index, fieldNumber int) (int, int, error) {
+	// the field numbers of the enclosing groups, only allocated
+	// if there are nested groups.
+	var parents []int
+
+	for {
+		start := index
+
+		var err error
+		var fn, wt int
+		index, fn, wt, err = readTag(data, index)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		switch wt {
+		case WireTypeStartGroup:
+			parents = append(parents, fieldNumber)
+			fieldNumber = fn
+		case WireTypeEndGroup:
+			if fn != fieldNumber {
+				return 0, 0, ErrInvalidGroup
+			}
+
+			if len(parents) == 0 {
+				return start, index, nil
+			}
+
+			fieldNumber = parents[len(parents)-1]
+			parents = parents[:len(parents)-1]
+		default:
+			index, err = skipValue(data, index, fn, wt)
+			if err != nil {
+				return 0, 0, err
+			}
+		}
+	}
 }
@@ -1,10 +1,13 @@
 package protoscan
 
 import (
+	"bytes"
+	"errors"
 	"io"
 	"testing"
 
 	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -193,9 +196,224 @@ func TestMessage_Next(t *testing.T) {
 		t.Errorf("should be false on if error")
 	}
 
-	if err := msg.Err(); err != ErrIntOverflow {
+	if err := msg.Err(); !errors.Is(err, ErrIntOverflow) {
 		t.Errorf("incorrect error: %v", err)
 	}
+
+	t.Run("invalid tags", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			fieldNumber protowire.Number
+			wireType    protowire.Type
+		}{
+			{
+				name:        "zero field number",
+				fieldNumber: 0,
+				wireType:    WireTypeVarint,
+			},
+			{
+				name:        "field number too large",
+				fieldNumber: 1 << 29,
+				wireType:    WireTypeVarint,
+			},
+			{
+				name:        "wire type 6",
+				fieldNumber: 1,
+				wireType:    6,
+			},
+			{
+				name:        "wire type 7",
+				fieldNumber: 1,
+				wireType:    7,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				data := protowire.AppendTag(nil, 1, WireTypeVarint)
+				data = protowire.AppendVarint(data, 1)
+				data = protowire.AppendVarint(data, protowire.EncodeTag(tc.fieldNumber, tc.wireType))
+				data = protowire.AppendVarint(data, 1)
+
+				msg := New(data)
+				if !msg.Next() {
+					t.Fatalf("first field should be valid")
+				}
+				msg.Skip()
+
+				if msg.Next() {
+					t.Errorf("should be false on invalid tag")
+				}
+
+				err := msg.Err()
+				if !errors.Is(err, ErrInvalidTag) {
+					t.Fatalf("incorrect error: %v", err)
+				}
+
+				var terr *TagError
+				if !errors.As(err, &terr) {
+					t.Fatalf("should be a tag error: %v", err)
+				}
+
+				if terr.FieldNumber != int(tc.fieldNumber) || terr.WireType != int(tc.wireType) {
+					t.Errorf("incorrect tag: %v %v", terr.FieldNumber, terr.WireType)
+				}
+
+				var derr *DecodeError
+				if !errors.As(err, &derr) {
+					t.Fatalf("should be a decode error: %v", err)
+				}
+
+				if derr.Index != 2 {
+					t.Errorf("incorrect index: %v", derr.Index)
+				}
+			})
+		}
+	})
+
+	t.Run("max field number", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1<<29-1, WireTypeVarint)
+		data = protowire.AppendVarint(data, 1)
+
+		msg := New(data)
+		if !msg.Next() {
+			t.Fatalf("should be valid: %v", msg.Err())
+		}
+
+		if v := msg.FieldNumber(); v != 1<<29-1 {
+			t.Errorf("incorrect field number: %v", v)
+		}
+	})
+
+	t.Run("invalid tag in group", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeStartGroup)
+		data = protowire.AppendVarint(data, protowire.EncodeTag(2, 7))
+		data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+
+		msg := New(data)
+		msg.Next()
+		msg.Skip()
+
+		if err := msg.Err(); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+
+	t.Run("end group without start group", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeVarint)
+		data = protowire.AppendVarint(data, 1)
+		data = protowire.AppendTag(data, 2, WireTypeEndGroup)
+
+		msg := New(data)
+		if !msg.Next() {
+			t.Fatalf("first field should be valid")
+		}
+		msg.Skip()
+
+		if msg.Next() {
+			t.Errorf("should be false on end group tag")
+		}
+
+		err := msg.Err()
+		if !errors.Is(err, ErrInvalidGroup) {
+			t.Fatalf("incorrect error: %v", err)
+		}
+
+		var derr *DecodeError
+		if !errors.As(err, &derr) {
+			t.Fatalf("should be a decode error: %v", err)
+		}
+
+		if derr.Index != 2 {
+			t.Errorf("incorrect index: %v", derr.Index)
+		}
+	})
+
+	t.Run("group contents", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeStartGroup)
+		data = protowire.AppendTag(data, 2, WireTypeVarint)
+		data = protowire.AppendVarint(data, 5)
+		data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+
+		msg := New(data)
+		msg.Next()
+
+		group, err := msg.Group(nil)
+		if err != nil {
+			t.Fatalf("unable to read group: %v", err)
+		}
+
+		for group.Next() {
+			group.Skip()
+		}
+
+		if err := group.Err(); err != nil {
+			t.Errorf("end group tag should not be in the group: %v", err)
+		}
+	})
+}
+
+func TestMessage_Peek(t *testing.T) {
+	data := protowire.AppendTag(nil, 1, WireTypeVarint)
+	data = protowire.AppendVarint(data, 100)
+	data = protowire.AppendTag(data, 1, WireTypeVarint)
+	data = protowire.AppendVarint(data, 200)
+	data = protowire.AppendTag(data, 2, WireTypeLengthDelimited)
+	data = protowire.AppendString(data, "abc")
+
+	msg := New(data)
+	if !msg.Next() {
+		t.Fatalf("next is false?")
+	}
+
+	if _, err := msg.Int64(); err != nil {
+		t.Fatalf("unable to read: %v", err)
+	}
+
+	index := msg.Index
+	fn, wt, ok := msg.Peek()
+	if !ok || fn != 1 || wt != WireTypeVarint {
+		t.Errorf("incorrect peek: %v %v %v", fn, wt, ok)
+	}
+
+	if msg.Index != index {
+		t.Errorf("index should not change: %v != %v", msg.Index, index)
+	}
+
+	if msg.FieldNumber() != 1 || msg.WireType() != WireTypeVarint {
+		t.Errorf("current field should not change")
+	}
+
+	msg.Next()
+	msg.Skip()
+
+	fn, wt, ok = msg.Peek()
+	if !ok || fn != 2 || wt != WireTypeLengthDelimited {
+		t.Errorf("incorrect peek: %v %v %v", fn, wt, ok)
+	}
+
+	msg.Next()
+	msg.Skip()
+
+	if _, _, ok := msg.Peek(); ok {
+		t.Errorf("should be false at the end of the message")
+	}
+
+	t.Run("invalid tag", func(t *testing.T) {
+		msg := New([]byte{0x00, 0x01})
+
+		if _, _, ok := msg.Peek(); ok {
+			t.Errorf("should be false for invalid tag")
+		}
+
+		if msg.Err() != nil {
+			t.Errorf("should not set error: %v", msg.Err())
+		}
+
+		if msg.Next() {
+			t.Errorf("next should be false for invalid tag")
+		}
+	})
 }
 
 func TestMessage_Skip(t *testing.T) {
@@ -209,7 +427,7 @@ func TestMessage_Skip(t *testing.T) {
 		t.Errorf("should be false on if error")
 	}
 
-	if err := msg.Err(); err != io.ErrUnexpectedEOF {
+	if err := msg.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
 		t.Errorf("incorrect error: %v", err)
 	}
 
@@ -223,7 +441,7 @@ func TestMessage_Skip(t *testing.T) {
 		t.Errorf("should be false on if error")
 	}
 
-	if err := msg.Err(); err != io.ErrUnexpectedEOF {
+	if err := msg.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
 		t.Errorf("incorrect error: %v", err)
 	}
 
@@ -237,11 +455,272 @@ func TestMessage_Skip(t *testing.T) {
 		t.Errorf("should be false on if error")
 	}
 
-	if err := msg.Err(); err != io.ErrUnexpectedEOF {
+	if err := msg.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
 		t.Errorf("incorrect error: %v", err)
 	}
 }
 
+func TestMessage_Skip_group(t *testing.T) {
+	data := []byte{}
+	data = protowire.AppendTag(data, 1, WireTypeStartGroup)
+	data = protowire.AppendTag(data, 2, WireTypeVarint)
+	data = protowire.AppendVarint(data, 100)
+	data = protowire.AppendTag(data, 3, WireTypeStartGroup)
+	data = protowire.AppendTag(data, 1, WireTypeStartGroup) // same field number as parent
+	data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 4, WireTypeLengthDelimited)
+	data = protowire.AppendString(data, "nested")
+	data = protowire.AppendTag(data, 3, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 32, WireTypeVarint)
+	data = protowire.AppendVarint(data, 1)
+
+	msg := New(data)
+
+	var fields []int
+	for msg.Next() {
+		fields = append(fields, msg.FieldNumber())
+		msg.Skip()
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	if len(fields) != 2 || fields[0] != 1 || fields[1] != 32 {
+		t.Errorf("incorrect fields: %v", fields)
+	}
+
+	t.Run("mismatched end group", func(t *testing.T) {
+		data := []byte{}
+		data = protowire.AppendTag(data, 1, WireTypeStartGroup)
+		data = protowire.AppendTag(data, 2, WireTypeEndGroup)
+
+		msg := New(data)
+		msg.Next()
+		msg.Skip()
+
+		if err := msg.Err(); !errors.Is(err, ErrInvalidGroup) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+
+	t.Run("missing end group", func(t *testing.T) {
+		data := []byte{}
+		data = protowire.AppendTag(data, 1, WireTypeStartGroup)
+		data = protowire.AppendTag(data, 2, WireTypeVarint)
+		data = protowire.AppendVarint(data, 100)
+
+		msg := New(data)
+		msg.Next()
+		msg.Skip()
+
+		if err := msg.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func TestMessage_Group(t *testing.T) {
+	data := []byte{}
+	data = protowire.AppendTag(data, 1, WireTypeStartGroup)
+	data = protowire.AppendTag(data, 2, WireTypeVarint)
+	data = protowire.AppendVarint(data, 100)
+	data = protowire.AppendTag(data, 3, WireTypeStartGroup)
+	data = protowire.AppendTag(data, 4, WireType64bit)
+	data = protowire.AppendFixed64(data, 200)
+	data = protowire.AppendTag(data, 3, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 32, WireTypeVarint)
+	data = protowire.AppendVarint(data, 1)
+
+	msg := New(data)
+
+	var (
+		v2, v4 uint64
+		after  bool
+		gmsg   *Message // for reuse
+	)
+
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 1:
+			group, err := msg.Group(nil)
+			if err != nil {
+				t.Fatalf("unable to read group: %v", err)
+			}
+
+			for group.Next() {
+				switch group.FieldNumber() {
+				case 2:
+					v2, err = group.Uint64()
+					if err != nil {
+						t.Fatalf("unable to read: %v", err)
+					}
+				case 3:
+					gmsg, err = group.Group(gmsg)
+					if err != nil {
+						t.Fatalf("unable to read nested group: %v", err)
+					}
+
+					for gmsg.Next() {
+						switch gmsg.FieldNumber() {
+						case 4:
+							v4, err = gmsg.Fixed64()
+							if err != nil {
+								t.Fatalf("unable to read: %v", err)
+							}
+						default:
+							gmsg.Skip()
+						}
+					}
+
+					if err := gmsg.Err(); err != nil {
+						t.Fatalf("nested group scanning error: %v", err)
+					}
+				default:
+					group.Skip()
+				}
+			}
+
+			if err := group.Err(); err != nil {
+				t.Fatalf("group scanning error: %v", err)
+			}
+		case 32:
+			v, err := msg.Bool()
+			if err != nil {
+				t.Fatalf("unable to read: %v", err)
+			}
+			after = v
+		default:
+			msg.Skip()
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	if v2 != 100 {
+		t.Errorf("incorrect group value: %v", v2)
+	}
+
+	if v4 != 200 {
+		t.Errorf("incorrect nested group value: %v", v4)
+	}
+
+	if !after {
+		t.Errorf("should read field after the group")
+	}
+
+	t.Run("empty group", func(t *testing.T) {
+		data := []byte{}
+		data = protowire.AppendTag(data, 1, WireTypeStartGroup)
+		data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+
+		msg := New(data)
+		msg.Next()
+
+		group, err := msg.Group(nil)
+		if err != nil {
+			t.Fatalf("unable to read group: %v", err)
+		}
+
+		if len(group.Data) != 0 {
+			t.Errorf("group should be empty: %v", group.Data)
+		}
+
+		if msg.Next() {
+			t.Errorf("should be at the end of the message")
+		}
+	})
+
+	t.Run("missing end group", func(t *testing.T) {
+		data := []byte{}
+		data = protowire.AppendTag(data, 1, WireTypeStartGroup)
+
+		msg := New(data)
+		msg.Next()
+
+		_, err := msg.Group(nil)
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func TestMessage_RawField(t *testing.T) {
+	data := protowire.AppendTag(nil, 1, WireTypeVarint)
+	data = protowire.AppendVarint(data, 300)
+	data = protowire.AppendTag(data, 2, WireTypeLengthDelimited)
+	data = protowire.AppendString(data, "abc")
+	data = protowire.AppendTag(data, 3, WireTypeStartGroup)
+	data = protowire.AppendTag(data, 4, WireType32bit)
+	data = protowire.AppendFixed32(data, 5)
+	data = protowire.AppendTag(data, 3, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 5, WireType64bit)
+	data = protowire.AppendFixed64(data, 6)
+
+	msg := New(data)
+
+	var fields []Field
+	for msg.Next() {
+		f, err := msg.RawField()
+		if err != nil {
+			t.Fatalf("unable to read field: %v", err)
+		}
+		fields = append(fields, f)
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	if len(fields) != 4 {
+		t.Fatalf("incorrect number of fields: %v", len(fields))
+	}
+
+	// the fields should be the complete data
+	var result []byte
+	for i, f := range fields {
+		if expected := []int{1, 2, 3, 5}[i]; f.Number != expected {
+			t.Errorf("incorrect field number: %v != %v", f.Number, expected)
+		}
+
+		result = append(result, f.Tag...)
+		result = append(result, f.Value...)
+	}
+
+	if !bytes.Equal(result, data) {
+		t.Errorf("fields should be the complete data")
+	}
+
+	if v := fields[1].Value; !bytes.Equal(v, []byte{3, 'a', 'b', 'c'}) {
+		t.Errorf("incorrect length delimited value: %v", v)
+	}
+
+	if v := fields[2].WireType; v != WireTypeStartGroup {
+		t.Errorf("incorrect wire type: %v", v)
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		msg := New(data[:len(data)-1])
+		for msg.Next() {
+			_, err := msg.RawField()
+			if err == nil {
+				continue
+			}
+
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("incorrect error: %v", err)
+			}
+			return
+		}
+
+		t.Errorf("should return an error")
+	})
+}
+
 func TestMessage_MessageData(t *testing.T) {
 	parent := &testmsg.Parent{
 		Child: &testmsg.Child{
@@ -306,7 +785,7 @@ func TestMessage_MessageData(t *testing.T) {
 		msg := New([]byte{200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200})
 		_, err := msg.MessageData()
 
-		if err != ErrIntOverflow {
+		if !errors.Is(err, ErrIntOverflow) {
 			t.Errorf("incorrect error: %v", err)
 		}
 	})
@@ -0,0 +1,79 @@
+package protoscan
+
+// PackedBoolBits decodes the current packed repeated bool field into a bitset,
+// value j is bit j%64 of word j/64. It returns the bitset, reusing dst if it has
+// the capacity, and the number of values. This uses 1/64 of the memory of the
+// []bool returned by RepeatedBool.
+func (m *Message) PackedBoolBits(dst []uint64) ([]uint64, int, error) {
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, 0, err
+	}
+
+	l, err := m.packedLength()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	words := (m.count(l) + 63) / 64
+	if cap(dst) < words {
+		dst = make([]uint64, words)
+	} else {
+		dst = dst[:words]
+		clear(dst)
+	}
+
+	n := 0
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		v, err := values.Bool()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if v {
+			dst[n/64] |= 1 << (n % 64)
+		}
+		n++
+	}
+
+	m.Index = values.Index
+	return dst, n, nil
+}
+
+// PackedEnum8 will append the values of the current packed repeated enum field
+// to the buffer as bytes. This is for enums with values known to be between
+// 0 and 255, it returns ErrIntOverflow if a value does not fit.
+func (m *Message) PackedEnum8(dst []uint8) ([]uint8, error) {
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
+	l, err := m.packedLength()
+	if err != nil {
+		return nil, err
+	}
+
+	// if provided we append.
+	if dst == nil {
+		dst = make([]uint8, 0, m.count(l))
+	}
+
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		index := values.Index
+		v, err := values.Varint64()
+		if err != nil {
+			return nil, err
+		}
+
+		if v > 255 {
+			values.Index = index
+			return nil, values.decodeError(ErrIntOverflow)
+		}
+
+		dst = append(dst, uint8(v))
+	}
+
+	m.Index = values.Index
+	return dst, nil
+}
@@ -0,0 +1,95 @@
+package protoscan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessage_PackedBoolBits(t *testing.T) {
+	message := &testmsg.Packed{}
+	for i := 0; i < 200; i++ {
+		message.Bool = append(message.Bool, i%3 == 0 || i == 199)
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data)
+	if !msg.Next() {
+		t.Fatalf("next is false?")
+	}
+
+	// should reuse and clear the buffer
+	buf := []uint64{1, 2, 3, 4, 5}
+	bits, n, err := msg.PackedBoolBits(buf)
+	if err != nil {
+		t.Fatalf("unable to read bits: %v", err)
+	}
+
+	if n != 200 {
+		t.Errorf("incorrect count: %v", n)
+	}
+
+	if len(bits) != 4 || &bits[0] != &buf[0] {
+		t.Errorf("incorrect bitset: %v", bits)
+	}
+
+	for i, v := range message.Bool {
+		if bit := bits[i/64]&(1<<(i%64)) != 0; bit != v {
+			t.Errorf("incorrect bit %d: %v != %v", i, bit, v)
+		}
+	}
+
+	if bits[3]>>(200%64) != 0 {
+		t.Errorf("bits after the values should be zero: %x", bits[3])
+	}
+
+	if msg.Next() {
+		t.Errorf("should have read the whole field")
+	}
+}
+
+func TestMessage_PackedEnum8(t *testing.T) {
+	data := protowire.AppendTag(nil, 1, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, []byte{0, 1, 0xff, 0x01, 2})
+
+	msg := New(data)
+	if !msg.Next() {
+		t.Fatalf("next is false?")
+	}
+
+	result, err := msg.PackedEnum8([]uint8{9})
+	if err != nil {
+		t.Fatalf("unable to read values: %v", err)
+	}
+
+	if string(result) != string([]byte{9, 0, 1, 255, 2}) {
+		t.Errorf("incorrect values: %v", result)
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeLengthDelimited)
+		data = protowire.AppendBytes(data, []byte{1, 0x80, 0x02})
+
+		msg := New(data)
+		if !msg.Next() {
+			t.Fatalf("next is false?")
+		}
+
+		_, err := msg.PackedEnum8(nil)
+		if !errors.Is(err, ErrIntOverflow) {
+			t.Fatalf("incorrect error: %v", err)
+		}
+
+		var derr *DecodeError
+		if !errors.As(err, &derr) || derr.Index != 3 {
+			t.Errorf("incorrect decode error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package protoscan
+
+// Project returns the encoded message with only the fields selected by the
+// field number paths. A path like {3, 1} selects field 1 of the embedded
+// messages, or groups, in field 3. The fields are copied without decoding
+// them and the lengths of the embedded messages are corrected. Embedded
+// messages and groups without any selected fields are left out. Empty paths
+// are ignored.
+func Project(data []byte, keep ...[]int) ([]byte, error) {
+	w := NewWriter(make([]byte, 0, len(data)))
+	if err := project(w, New(data), buildFieldPaths(keep), true); err != nil {
+		return nil, err
+	}
+
+	return w.Data, nil
+}
+
+// Drop returns the encoded message without the fields selected by the
+// field number paths, see Project.
+func Drop(data []byte, drop ...[]int) ([]byte, error) {
+	w := NewWriter(make([]byte, 0, len(data)))
+	if err := project(w, New(data), buildFieldPaths(drop), false); err != nil {
+		return nil, err
+	}
+
+	return w.Data, nil
+}
+
+// fieldPaths is a tree of the field numbers in a set of paths.
+// A leaf selects the whole field, including all its embedded fields.
+type fieldPaths struct {
+	leaf   bool
+	fields map[int]*fieldPaths
+}
+
+func buildFieldPaths(paths [][]int) *fieldPaths {
+	root := &fieldPaths{}
+	for _, path := range paths {
+		node := root
+		for _, n := range path {
+			if node.leaf {
+				break // a shorter path already selects the whole field
+			}
+
+			if node.fields == nil {
+				node.fields = make(map[int]*fieldPaths)
+			}
+
+			child := node.fields[n]
+			if child == nil {
+				child = &fieldPaths{}
+				node.fields[n] = child
+			}
+			node = child
+		}
+
+		if node != root {
+			node.leaf = true
+			node.fields = nil
+		}
+	}
+
+	return root
+}
+
+// project copies the fields of the message that are selected by the paths,
+// if keep is true, or the fields that are not selected if keep is false.
+func project(w *Writer, msg *Message, paths *fieldPaths, keep bool) error {
+	for msg.Next() {
+		child := paths.fields[msg.FieldNumber()]
+		if child == nil || child.leaf {
+			if (child != nil) == keep {
+				if err := w.CopyField(msg); err != nil {
+					return err
+				}
+			} else {
+				msg.Skip()
+			}
+			continue
+		}
+
+		// the path continues into the embedded message or group.
+		field := msg.FieldNumber()
+		switch msg.WireType() {
+		case WireTypeLengthDelimited:
+			embedded, err := msg.Message(nil)
+			if err != nil {
+				return err
+			}
+
+			tagIndex := len(w.Data)
+			w.BeginMessage(field)
+			if err := project(w, embedded, child, keep); err != nil {
+				return err
+			}
+
+			// when projecting, messages without any of the
+			// selected fields are left out.
+			if !keep || !w.removeEmpty(tagIndex) {
+				w.End()
+			}
+		case WireTypeStartGroup:
+			group, err := msg.Group(nil)
+			if err != nil {
+				return err
+			}
+
+			tagIndex := len(w.Data)
+			w.tag(field, WireTypeStartGroup)
+			start := len(w.Data)
+			if err := project(w, group, child, keep); err != nil {
+				return err
+			}
+
+			if keep && len(w.Data) == start {
+				w.Data = w.Data[:tagIndex]
+			} else {
+				w.tag(field, WireTypeEndGroup)
+			}
+		default:
+			// a scalar value can not contain the rest of the path,
+			// so nothing in it is selected.
+			if keep {
+				msg.Skip()
+			} else if err := w.CopyField(msg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return msg.Err()
+}
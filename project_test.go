@@ -0,0 +1,228 @@
+package protoscan
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProject(t *testing.T) {
+	customer := testCustomer()
+	data, err := proto.Marshal(customer)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	projected, err := Project(data, []int{1}, []int{3, 1}, []int{3, 3, 1})
+	if err != nil {
+		t.Fatalf("unable to project: %v", err)
+	}
+
+	expected := &testmsg.Customer{Id: customer.Id}
+	for _, o := range customer.Orders {
+		order := &testmsg.Order{Id: o.Id}
+		for _, i := range o.Items {
+			order.Items = append(order.Items, &testmsg.Item{Id: i.Id})
+		}
+		expected.Orders = append(expected.Orders, order)
+	}
+
+	result := &testmsg.Customer{}
+	err = proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(projected, result)
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %v", err)
+	}
+
+	compare(t, result, expected)
+
+	t.Run("whole field", func(t *testing.T) {
+		// the shorter path selects all of field 3
+		projected, err := Project(data, []int{3, 1}, []int{3}, []int{})
+		if err != nil {
+			t.Fatalf("unable to project: %v", err)
+		}
+
+		result := &testmsg.Customer{}
+		err = proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(projected, result)
+		if err != nil {
+			t.Fatalf("unable to unmarshal: %v", err)
+		}
+
+		compare(t, result, &testmsg.Customer{Orders: customer.Orders})
+	})
+
+	t.Run("nothing", func(t *testing.T) {
+		projected, err := Project(data)
+		if err != nil {
+			t.Fatalf("unable to project: %v", err)
+		}
+
+		if len(projected) != 0 {
+			t.Errorf("should be empty: %v", projected)
+		}
+	})
+}
+
+func TestDrop(t *testing.T) {
+	customer := testCustomer()
+	data, err := proto.Marshal(customer)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	dropped, err := Drop(data, []int{2}, []int{3, 3})
+	if err != nil {
+		t.Fatalf("unable to drop: %v", err)
+	}
+
+	expected := proto.Clone(customer).(*testmsg.Customer)
+	expected.Username = nil
+	for _, o := range expected.Orders {
+		o.Items = nil
+	}
+
+	result := &testmsg.Customer{}
+	err = proto.Unmarshal(dropped, result)
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %v", err)
+	}
+
+	compare(t, result, expected)
+
+	t.Run("nothing", func(t *testing.T) {
+		dropped, err := Drop(data)
+		if err != nil {
+			t.Fatalf("unable to drop: %v", err)
+		}
+
+		if !bytes.Equal(dropped, data) {
+			t.Errorf("should not change the data")
+		}
+	})
+}
+
+func TestProject_group(t *testing.T) {
+	group := protowire.AppendTag(nil, 1, WireTypeVarint)
+	group = protowire.AppendVarint(group, 5)
+	group = protowire.AppendTag(group, 2, WireTypeLengthDelimited)
+	group = protowire.AppendString(group, strings.Repeat("a", 200))
+
+	data := protowire.AppendTag(nil, 1, WireTypeStartGroup)
+	data = append(data, group...)
+	data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 2, WireTypeVarint)
+	data = protowire.AppendVarint(data, 10)
+
+	projected, err := Project(data, []int{1, 1})
+	if err != nil {
+		t.Fatalf("unable to project: %v", err)
+	}
+
+	expected := protowire.AppendTag(nil, 1, WireTypeStartGroup)
+	expected = protowire.AppendTag(expected, 1, WireTypeVarint)
+	expected = protowire.AppendVarint(expected, 5)
+	expected = protowire.AppendTag(expected, 1, WireTypeEndGroup)
+
+	if !bytes.Equal(projected, expected) {
+		t.Errorf("incorrect data:\n%v\n%v", projected, expected)
+	}
+}
+
+func TestProject_notSelected(t *testing.T) {
+	customer := testCustomer()
+	data, err := proto.Marshal(customer)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	t.Run("embedded messages", func(t *testing.T) {
+		projected, err := Project(data, []int{3, 9})
+		if err != nil {
+			t.Fatalf("unable to project: %v", err)
+		}
+
+		if len(projected) != 0 {
+			t.Errorf("should not have empty embedded messages: %v", projected)
+		}
+	})
+
+	t.Run("group", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeStartGroup)
+		data = protowire.AppendTag(data, 2, WireTypeVarint)
+		data = protowire.AppendVarint(data, 5)
+		data = protowire.AppendTag(data, 1, WireTypeEndGroup)
+
+		projected, err := Project(data, []int{1, 3})
+		if err != nil {
+			t.Fatalf("unable to project: %v", err)
+		}
+
+		if len(projected) != 0 {
+			t.Errorf("should not have empty groups: %v", projected)
+		}
+	})
+
+	t.Run("path into scalar", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeVarint)
+		data = protowire.AppendVarint(data, 5)
+
+		projected, err := Project(data, []int{1, 2})
+		if err != nil {
+			t.Fatalf("unable to project: %v", err)
+		}
+
+		if len(projected) != 0 {
+			t.Errorf("should be empty: %v", projected)
+		}
+
+		dropped, err := Drop(data, []int{1, 2})
+		if err != nil {
+			t.Fatalf("unable to drop: %v", err)
+		}
+
+		if !bytes.Equal(dropped, data) {
+			t.Errorf("should keep the scalar: %v", dropped)
+		}
+	})
+}
+
+func TestProject_errors(t *testing.T) {
+	t.Run("invalid data", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 1, WireTypeLengthDelimited)
+		data = protowire.AppendVarint(data, 5)
+
+		_, err := Drop(data, []int{1, 2})
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
+
+func testCustomer() *testmsg.Customer {
+	customer := &testmsg.Customer{
+		Id:          proto.Int64(123),
+		Username:    proto.String("name"),
+		FavoriteIds: []int64{1, 2, 3},
+	}
+
+	for i := 0; i < 3; i++ {
+		order := &testmsg.Order{
+			Id:   proto.Int64(int64(i)),
+			Open: proto.Bool(i%2 == 0),
+		}
+
+		for j := 0; j < 20; j++ {
+			order.Items = append(order.Items, &testmsg.Item{Id: proto.Int64(int64(1000 * j))})
+		}
+
+		customer.Orders = append(customer.Orders, order)
+	}
+
+	return customer
+}
@@ -2,6 +2,12 @@
 
 package protoscan
 
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
 // RepeatedFloat will append the repeated value(s) to the buffer.
 // This method supports packed or unpacked encoding.
 func (m *Message) RepeatedFloat(buf []float32) ([]float32, error) {
@@ -14,6 +20,10 @@ func (m *Message) RepeatedFloat(buf []float32) ([]float32, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -24,16 +34,20 @@ func (m *Message) RepeatedFloat(buf []float32) ([]float32, error) {
 		buf = make([]float32, 0, l/4)
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Float()
-		if err != nil {
-			return nil, err
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireType32bit)
+	for values.Index < len(values.Data) {
+		if len(values.Data) < values.Index+4 {
+			return nil, values.decodeError(io.ErrUnexpectedEOF)
 		}
 
-		buf = append(buf, v)
+		v := binary.LittleEndian.Uint32(values.Data[values.Index:])
+		values.Index += 4
+		buf = append(buf, math.Float32frombits(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -49,6 +63,10 @@ func (m *Message) RepeatedDouble(buf []float64) ([]float64, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -59,16 +77,20 @@ func (m *Message) RepeatedDouble(buf []float64) ([]float64, error) {
 		buf = make([]float64, 0, l/8)
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Double()
-		if err != nil {
-			return nil, err
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireType64bit)
+	for values.Index < len(values.Data) {
+		if len(values.Data) < values.Index+8 {
+			return nil, values.decodeError(io.ErrUnexpectedEOF)
 		}
 
-		buf = append(buf, v)
+		v := binary.LittleEndian.Uint64(values.Data[values.Index:])
+		values.Index += 8
+		buf = append(buf, math.Float64frombits(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -84,6 +106,10 @@ func (m *Message) RepeatedInt32(buf []int32) ([]int32, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -94,16 +120,20 @@ func (m *Message) RepeatedInt32(buf []int32) ([]int32, error) {
 		buf = make([]int32, 0, m.count(l))
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Int32()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		next, v, err := varint64(values.Data, values.Index)
 		if err != nil {
-			return nil, err
+			return nil, values.decodeError(err)
 		}
 
-		buf = append(buf, v)
+		values.Index = next
+		buf = append(buf, int32(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -119,6 +149,10 @@ func (m *Message) RepeatedInt64(buf []int64) ([]int64, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -129,16 +163,20 @@ func (m *Message) RepeatedInt64(buf []int64) ([]int64, error) {
 		buf = make([]int64, 0, m.count(l))
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Int64()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		next, v, err := varint64(values.Data, values.Index)
 		if err != nil {
-			return nil, err
+			return nil, values.decodeError(err)
 		}
 
-		buf = append(buf, v)
+		values.Index = next
+		buf = append(buf, int64(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -154,6 +192,10 @@ func (m *Message) RepeatedUint32(buf []uint32) ([]uint32, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -164,16 +206,20 @@ func (m *Message) RepeatedUint32(buf []uint32) ([]uint32, error) {
 		buf = make([]uint32, 0, m.count(l))
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Uint32()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		next, v, err := varint32(values.Data, values.Index)
 		if err != nil {
-			return nil, err
+			return nil, values.decodeError(err)
 		}
 
-		buf = append(buf, v)
+		values.Index = next
+		buf = append(buf, uint32(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -189,6 +235,10 @@ func (m *Message) RepeatedUint64(buf []uint64) ([]uint64, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -199,16 +249,20 @@ func (m *Message) RepeatedUint64(buf []uint64) ([]uint64, error) {
 		buf = make([]uint64, 0, m.count(l))
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Uint64()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		next, v, err := varint64(values.Data, values.Index)
 		if err != nil {
-			return nil, err
+			return nil, values.decodeError(err)
 		}
 
+		values.Index = next
 		buf = append(buf, v)
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -224,6 +278,10 @@ func (m *Message) RepeatedSint32(buf []int32) ([]int32, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -234,16 +292,20 @@ func (m *Message) RepeatedSint32(buf []int32) ([]int32, error) {
 		buf = make([]int32, 0, m.count(l))
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Sint32()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		next, v, err := varint64(values.Data, values.Index)
 		if err != nil {
-			return nil, err
+			return nil, values.decodeError(err)
 		}
 
-		buf = append(buf, v)
+		values.Index = next
+		buf = append(buf, int32(unZig64(v)))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -259,6 +321,10 @@ func (m *Message) RepeatedSint64(buf []int64) ([]int64, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -269,16 +335,20 @@ func (m *Message) RepeatedSint64(buf []int64) ([]int64, error) {
 		buf = make([]int64, 0, m.count(l))
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Sint64()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		next, v, err := varint64(values.Data, values.Index)
 		if err != nil {
-			return nil, err
+			return nil, values.decodeError(err)
 		}
 
-		buf = append(buf, v)
+		values.Index = next
+		buf = append(buf, unZig64(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -294,6 +364,10 @@ func (m *Message) RepeatedFixed32(buf []uint32) ([]uint32, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -304,16 +378,20 @@ func (m *Message) RepeatedFixed32(buf []uint32) ([]uint32, error) {
 		buf = make([]uint32, 0, l/4)
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Fixed32()
-		if err != nil {
-			return nil, err
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireType32bit)
+	for values.Index < len(values.Data) {
+		if len(values.Data) < values.Index+4 {
+			return nil, values.decodeError(io.ErrUnexpectedEOF)
 		}
 
+		v := binary.LittleEndian.Uint32(values.Data[values.Index:])
+		values.Index += 4
 		buf = append(buf, v)
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -329,6 +407,10 @@ func (m *Message) RepeatedFixed64(buf []uint64) ([]uint64, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -339,16 +421,20 @@ func (m *Message) RepeatedFixed64(buf []uint64) ([]uint64, error) {
 		buf = make([]uint64, 0, l/8)
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Fixed64()
-		if err != nil {
-			return nil, err
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireType64bit)
+	for values.Index < len(values.Data) {
+		if len(values.Data) < values.Index+8 {
+			return nil, values.decodeError(io.ErrUnexpectedEOF)
 		}
 
+		v := binary.LittleEndian.Uint64(values.Data[values.Index:])
+		values.Index += 8
 		buf = append(buf, v)
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -364,6 +450,10 @@ func (m *Message) RepeatedSfixed32(buf []int32) ([]int32, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -374,16 +464,20 @@ func (m *Message) RepeatedSfixed32(buf []int32) ([]int32, error) {
 		buf = make([]int32, 0, l/4)
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Sfixed32()
-		if err != nil {
-			return nil, err
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireType32bit)
+	for values.Index < len(values.Data) {
+		if len(values.Data) < values.Index+4 {
+			return nil, values.decodeError(io.ErrUnexpectedEOF)
 		}
 
-		buf = append(buf, v)
+		v := binary.LittleEndian.Uint32(values.Data[values.Index:])
+		values.Index += 4
+		buf = append(buf, int32(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -399,6 +493,10 @@ func (m *Message) RepeatedSfixed64(buf []int64) ([]int64, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -409,16 +507,20 @@ func (m *Message) RepeatedSfixed64(buf []int64) ([]int64, error) {
 		buf = make([]int64, 0, l/8)
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Sfixed64()
-		if err != nil {
-			return nil, err
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireType64bit)
+	for values.Index < len(values.Data) {
+		if len(values.Data) < values.Index+8 {
+			return nil, values.decodeError(io.ErrUnexpectedEOF)
 		}
 
-		buf = append(buf, v)
+		v := binary.LittleEndian.Uint64(values.Data[values.Index:])
+		values.Index += 8
+		buf = append(buf, int64(v))
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
 
@@ -434,6 +536,10 @@ func (m *Message) RepeatedBool(buf []bool) ([]bool, error) {
 		return append(buf, v), nil
 	}
 
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -444,15 +550,19 @@ func (m *Message) RepeatedBool(buf []bool) ([]bool, error) {
 		buf = make([]bool, 0, l)
 	}
 
-	postIndex := m.Index + l
-	for m.Index < postIndex {
-		v, err := m.Bool()
+	// values are decoded here, and not with the accessor, so the
+	// decoding inlines into the loop.
+	values := m.packed(l, WireTypeVarint)
+	for values.Index < len(values.Data) {
+		next, v, err := varint64(values.Data, values.Index)
 		if err != nil {
-			return nil, err
+			return nil, values.decodeError(err)
 		}
 
-		buf = append(buf, v)
+		values.Index = next
+		buf = append(buf, v == 1)
 	}
 
+	m.Index = values.Index
 	return buf, nil
 }
@@ -0,0 +1,94 @@
+package protoscan
+
+// A RepeatedIterator reads all the values of a repeated scalar field in a
+// message. The field can be packed, unpacked or a mix of both. The values
+// are read directly from the message data, nothing is allocated.
+type RepeatedIterator struct {
+	base
+
+	msg Message
+	err error
+}
+
+// RepeatedIterator returns an iterator over all the occurrences of the repeated
+// field in the message. The current position of the Message is not changed.
+//
+//	iter := msg.RepeatedIterator(3)
+//	for iter.HasNext() {
+//	  v, err := iter.Int64()
+//	}
+//
+//	if err := iter.Err(); err != nil {
+//	  // handle
+//	}
+func (m *Message) RepeatedIterator(fieldNumber int) *RepeatedIterator {
+	iter := &RepeatedIterator{
+		base: base{
+			fieldNumber: fieldNumber,
+			path:        m.path,
+			strict:      m.strict,
+		},
+		msg: *m,
+	}
+	iter.msg.Reset(nil)
+
+	return iter
+}
+
+// HasNext moves to the next packed chunk or unpacked value of the field if needed.
+// It must be called before reading each value. Returns false when all the values
+// have been read or there is an error, check Err() to tell the difference.
+func (r *RepeatedIterator) HasNext() bool {
+	if r.Index < len(r.Data) {
+		return true
+	}
+
+	for r.err == nil && r.msg.Find(r.fieldNumber) {
+		start := r.msg.Index
+
+		if r.msg.wireType == WireTypeLengthDelimited {
+			l, err := r.msg.packedLength()
+			if err != nil {
+				r.err = err
+				return false
+			}
+
+			r.Data = r.msg.Data[r.msg.Index : r.msg.Index+l]
+			r.msg.Index += l
+			r.wireType = WireTypeLengthDelimited
+			if r.strict {
+				r.wireType = wireTypePacked
+			}
+		} else {
+			r.msg.Skip()
+			if r.msg.err != nil {
+				break
+			}
+
+			r.Data = r.msg.Data[start:r.msg.Index]
+			r.wireType = r.msg.wireType
+		}
+
+		r.Index = 0
+		if len(r.Data) > 0 {
+			return true
+		}
+	}
+
+	if r.err == nil {
+		r.err = r.msg.Err()
+	}
+
+	return false
+}
+
+// Err returns the error, if any, encountered by HasNext.
+// Errors reading values are returned by the value accessors.
+func (r *RepeatedIterator) Err() error {
+	return r.err
+}
+
+// FieldNumber returns the number for the repeated field.
+func (r *RepeatedIterator) FieldNumber() int {
+	return r.fieldNumber
+}
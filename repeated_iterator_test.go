@@ -0,0 +1,99 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessage_RepeatedIterator(t *testing.T) {
+	// a mix of packed and unpacked encoding of the same field
+	data := protowire.AppendTag(nil, 4, WireTypeVarint)
+	data = protowire.AppendVarint(data, 1)
+	data = protowire.AppendTag(data, 1, WireType32bit)
+	data = protowire.AppendFixed32(data, 100)
+	data = protowire.AppendTag(data, 4, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, []byte{2, 200, 1, 3})
+	data = protowire.AppendTag(data, 4, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, nil)
+	data = protowire.AppendTag(data, 4, WireTypeVarint)
+	data = protowire.AppendVarint(data, 5)
+
+	msg := New(data, StrictWireTypes())
+
+	iter := msg.RepeatedIterator(4)
+	if v := iter.FieldNumber(); v != 4 {
+		t.Errorf("incorrect field number: %v", v)
+	}
+
+	var values []int64
+	for iter.HasNext() {
+		v, err := iter.Int64()
+		if err != nil {
+			t.Fatalf("unable to read: %v", err)
+		}
+		values = append(values, v)
+	}
+
+	if err := iter.Err(); err != nil {
+		t.Fatalf("iterator error: %v", err)
+	}
+
+	compare(t, values, []int64{1, 2, 200, 3, 5})
+
+	if msg.Index != 0 {
+		t.Errorf("message should not be moved: %v", msg.Index)
+	}
+
+	t.Run("generated data", func(t *testing.T) {
+		message := &testmsg.Repeated{
+			F64:   []uint64{1, 2, 3},
+			After: proto.Bool(true),
+		}
+
+		for _, m := range []proto.Message{message, repeatedToPacked(message)} {
+			data, err := proto.Marshal(m)
+			if err != nil {
+				t.Fatalf("unable to marshal: %v", err)
+			}
+
+			var values []uint64
+			iter := New(data).RepeatedIterator(10)
+			for iter.HasNext() {
+				v, err := iter.Fixed64()
+				if err != nil {
+					t.Fatalf("unable to read: %v", err)
+				}
+				values = append(values, v)
+			}
+
+			compare(t, values, message.F64)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		iter := New(data).RepeatedIterator(2)
+		if iter.HasNext() {
+			t.Errorf("should not have values")
+		}
+
+		if err := iter.Err(); err != nil {
+			t.Errorf("should not be an error: %v", err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		iter := New(data[:len(data)-1]).RepeatedIterator(4)
+		for iter.HasNext() {
+			_, _ = iter.Int64()
+		}
+
+		if err := iter.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package protoscan
 
 import (
+	"errors"
 	"io"
 	"log"
 	"reflect"
@@ -221,7 +222,7 @@ func TestDecodeRepeated_packable(t *testing.T) {
 			}
 
 			r, err := decodeRepeated(t, data1[:1], 0, true)
-			if err != io.ErrUnexpectedEOF {
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
 				log.Printf("%v", r)
 				t.Errorf("incorrect error: %v", err)
 			}
@@ -232,7 +233,7 @@ func TestDecodeRepeated_packable(t *testing.T) {
 			}
 
 			_, err = decodeRepeated(t, data2[:2], 0, true)
-			if err != io.ErrUnexpectedEOF {
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
 				t.Errorf("incorrect error: %v", err)
 			}
 		})
@@ -371,6 +372,52 @@ func repeatedToPacked(r *testmsg.Repeated) *testmsg.Packed {
 	}
 }
 
+func TestDecodeRepeated_strictWireTypes(t *testing.T) {
+	message := &testmsg.Repeated{
+		I64: []int64{1, 2, 3},
+		F64: []uint64{4, 5, 6},
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	packed, err := proto.Marshal(repeatedToPacked(message))
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	for _, d := range [][]byte{data, packed} {
+		msg := New(d, StrictWireTypes())
+
+		var (
+			i64 []int64
+			f64 []uint64
+		)
+		for msg.Next() {
+			switch msg.FieldNumber() {
+			case 4:
+				i64, err = msg.RepeatedInt64(i64)
+			case 10:
+				f64, err = msg.RepeatedFixed64(f64)
+			default:
+				msg.Skip()
+			}
+
+			if err != nil {
+				t.Fatalf("unable to read: %v", err)
+			}
+		}
+
+		if err := msg.Err(); err != nil {
+			t.Fatalf("scanning error: %v", err)
+		}
+
+		compare(t, &testmsg.Repeated{I64: i64, F64: f64}, message)
+	}
+}
+
 func decodeRepeated(t *testing.T, data []byte, skip int, returnErr bool) (*testmsg.Repeated, error) {
 	msg := New(data)
 
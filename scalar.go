@@ -9,8 +9,12 @@ import (
 // Fixed32 reads a fixed 4 byte value as a uint32. This proto type is
 // more efficient than uint32 if values are often greater than 2^28.
 func (b *base) Fixed32() (uint32, error) {
+	if err := b.checkWireType(WireType32bit); err != nil {
+		return 0, err
+	}
+
 	if len(b.Data) < b.Index+4 {
-		return 0, io.ErrUnexpectedEOF
+		return 0, b.decodeError(io.ErrUnexpectedEOF)
 	}
 
 	v := binary.LittleEndian.Uint32(b.Data[b.Index:])
@@ -21,8 +25,12 @@ func (b *base) Fixed32() (uint32, error) {
 // Fixed64 reads a fixed 8 byte value as an uint64. This proto type is
 // more efficient than uint64 if values are often greater than 2^56.
 func (b *base) Fixed64() (uint64, error) {
+	if err := b.checkWireType(WireType64bit); err != nil {
+		return 0, err
+	}
+
 	if len(b.Data) < b.Index+8 {
-		return 0, io.ErrUnexpectedEOF
+		return 0, b.decodeError(io.ErrUnexpectedEOF)
 	}
 
 	v := binary.LittleEndian.Uint64(b.Data[b.Index:])
@@ -46,11 +54,17 @@ func (b *base) Sfixed64() (int64, error) {
 // Note that negative int32 values could still be encoded
 // as 64-bit varints due to their leading 1s.
 func (b *base) Varint32() (uint32, error) {
-	var v uint32
-	var err error
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
+
+	index, v, err := varint32(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
 
-	b.Index, v, err = varint32(b.Data, b.Index)
-	return v, err
+	b.Index = index
+	return v, nil
 }
 
 func varint32(data []byte, index int) (int, uint32, error) {
@@ -80,11 +94,17 @@ loop:
 
 // Varint64 reads up to 64-bits of variable-length encoded data.
 func (b *base) Varint64() (uint64, error) {
-	var v uint64
-	var err error
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
 
-	b.Index, v, err = varint64(b.Data, b.Index)
-	return v, err
+	index, v, err := varint64(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
+
+	b.Index = index
+	return v, nil
 }
 
 func varint64(data []byte, index int) (int, uint64, error) {
@@ -127,74 +147,118 @@ func (b *base) Float() (float32, error) {
 // best used if the field only has positive numbers, otherwise use sint32.
 // Note, this field can also by read as an Int64.
 func (b *base) Int32() (int32, error) {
-	var v uint64
-	var err error
-	b.Index, v, err = varint64(b.Data, b.Index)
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
 
-	return int32(v), err
+	index, v, err := varint64(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
+
+	b.Index = index
+	return int32(v), nil
 }
 
 // Int64 reads a variable-length encoding of up to 8 bytes. This field type is
 // best used if the field only has positive numbers, otherwise use sint64.
 func (b *base) Int64() (int64, error) {
-	var v uint64
-	var err error
-	b.Index, v, err = varint64(b.Data, b.Index)
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
 
-	return int64(v), err
+	index, v, err := varint64(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
+
+	b.Index = index
+	return int64(v), nil
 }
 
 // Uint32 reads a variable-length encoding of up to 4 bytes.
 func (b *base) Uint32() (uint32, error) {
-	var v uint32
-	var err error
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
 
-	b.Index, v, err = varint32(b.Data, b.Index)
-	return v, err
+	index, v, err := varint32(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
+
+	b.Index = index
+	return v, nil
 }
 
 // Uint64 reads a variable-length encoding of up to 8 bytes.
 func (b *base) Uint64() (uint64, error) {
-	var v uint64
-	var err error
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
 
-	b.Index, v, err = varint64(b.Data, b.Index)
-	return v, err
+	index, v, err := varint64(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
+
+	b.Index = index
+	return v, nil
 }
 
 // Sint32 uses variable-length encoding with zig-zag encoding for signed values.
 // This field type more efficiently encodes negative numbers than regular int32s.
 func (b *base) Sint32() (int32, error) {
-	var v uint64
-	var err error
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
+
+	index, v, err := varint64(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
 
-	b.Index, v, err = varint64(b.Data, b.Index)
-	return int32(unZig64(v)), err
+	b.Index = index
+	return int32(unZig64(v)), nil
 }
 
 // Sint64 uses variable-length encoding with zig-zag encoding for signed values.
 // This field type more efficiently encodes negative numbers than regular int64s.
 func (b *base) Sint64() (int64, error) {
-	var v uint64
-	var err error
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return 0, err
+	}
+
+	index, v, err := varint64(b.Data, b.Index)
+	if err != nil {
+		return 0, b.decodeError(err)
+	}
 
-	b.Index, v, err = varint64(b.Data, b.Index)
-	return unZig64(v), err
+	b.Index = index
+	return unZig64(v), nil
 }
 
 // Bool is encoded as 0x01 or 0x00 plus the field+type prefix byte. 2 bytes total.
 func (b *base) Bool() (bool, error) {
+	if err := b.checkWireType(WireTypeVarint); err != nil {
+		return false, err
+	}
+
 	if len(b.Data) <= b.Index {
-		return false, io.ErrUnexpectedEOF
+		return false, b.decodeError(io.ErrUnexpectedEOF)
 	}
 	if d := b.Data[b.Index]; d&0x80 == 0 {
 		b.Index++
 		return d == 1, nil
 	}
-	var v uint64
-	var err error
-	b.Index, v, err = varint64(b.Data, b.Index)
-	return v == 1, err
+
+	index, v, err := varint64(b.Data, b.Index)
+	if err != nil {
+		return false, b.decodeError(err)
+	}
+
+	b.Index = index
+	return v == 1, nil
 }
 
 // String reads a string type. This data will always contain UTF-8 encoded or
@@ -207,6 +271,10 @@ func (m *Message) String() (string, error) {
 // Bytes returns the encode sequence of bytes.
 // NOTE: this value is NOT copied.
 func (m *Message) Bytes() ([]byte, error) {
+	if err := m.checkWireType(WireTypeLengthDelimited); err != nil {
+		return nil, err
+	}
+
 	l, err := m.packedLength()
 	if err != nil {
 		return nil, err
@@ -217,6 +285,27 @@ func (m *Message) Bytes() ([]byte, error) {
 	return b, nil
 }
 
+// checkWireType returns an error, in strict mode, if the current
+// value is not encoded using the expected wire type.
+func (b *base) checkWireType(expected int) error {
+	if b.strict && b.wireType != expected {
+		return b.wireTypeMismatch(expected)
+	}
+
+	return nil
+}
+
+func (b *base) wireTypeMismatch(expected int) error {
+	if b.wireType == wireTypePacked {
+		// the first value read from an iterator sets the
+		// wire type of the values in the packed field.
+		b.wireType = expected
+		return nil
+	}
+
+	return b.decodeError(&WireTypeError{Expected: expected, Found: b.wireType})
+}
+
 func unZig64(v uint64) int64 {
 	return int64((v >> 1) ^ uint64((int64(v&1)<<63)>>63))
 }
@@ -3,6 +3,7 @@ package protoscan
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"testing"
 
@@ -449,7 +450,7 @@ func TestMessage_Varint32(t *testing.T) {
 	t.Run("overflow", func(t *testing.T) {
 		msg := New([]byte{230, 230, 230, 230, 230, 230})
 		_, err := msg.Varint32()
-		if err != ErrIntOverflow {
+		if !errors.Is(err, ErrIntOverflow) {
 			t.Errorf("wrong error: %v", err)
 		}
 	})
@@ -457,7 +458,7 @@ func TestMessage_Varint32(t *testing.T) {
 	t.Run("end of input", func(t *testing.T) {
 		msg := New([]byte{230, 230})
 		_, err := msg.Varint32()
-		if err != io.ErrUnexpectedEOF {
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
 			t.Errorf("wrong error: %v", err)
 		}
 	})
@@ -467,7 +468,7 @@ func TestMessage_Varint64(t *testing.T) {
 	t.Run("overflow", func(t *testing.T) {
 		msg := New([]byte{230, 230, 230, 230, 230, 230, 230, 230, 230, 230})
 		_, err := msg.Varint64()
-		if err != ErrIntOverflow {
+		if !errors.Is(err, ErrIntOverflow) {
 			t.Errorf("wrong error: %v", err)
 		}
 	})
@@ -475,7 +476,7 @@ func TestMessage_Varint64(t *testing.T) {
 	t.Run("end of input", func(t *testing.T) {
 		msg := New([]byte{230, 230})
 		_, err := msg.Varint64()
-		if err != io.ErrUnexpectedEOF {
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
 			t.Errorf("wrong error: %v", err)
 		}
 	})
@@ -519,6 +520,176 @@ func TestMessage_Int32(t *testing.T) {
 	}
 }
 
+func TestMessage_strictWireTypes(t *testing.T) {
+	message := &testmsg.Scalar{
+		Flt: proto.Float32(1.5),
+		Dbl: proto.Float64(2.5),
+		I64: proto.Int64(123),
+		Str: proto.String("abc"),
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		field    int
+		read     func(m *Message) error
+		expected int
+	}{
+		{
+			name:  "int64 as int64",
+			field: 4,
+			read: func(m *Message) error {
+				_, err := m.Int64()
+				return err
+			},
+			expected: -1,
+		},
+		{
+			name:  "string as int64",
+			field: 14,
+			read: func(m *Message) error {
+				_, err := m.Int64()
+				return err
+			},
+			expected: WireTypeVarint,
+		},
+		{
+			name:  "int64 as bytes",
+			field: 4,
+			read: func(m *Message) error {
+				_, err := m.Bytes()
+				return err
+			},
+			expected: WireTypeLengthDelimited,
+		},
+		{
+			name:  "double as float",
+			field: 2,
+			read: func(m *Message) error {
+				_, err := m.Float()
+				return err
+			},
+			expected: WireType32bit,
+		},
+		{
+			name:  "float as bool",
+			field: 1,
+			read: func(m *Message) error {
+				_, err := m.Bool()
+				return err
+			},
+			expected: WireTypeVarint,
+		},
+		{
+			name:  "string as message",
+			field: 14,
+			read: func(m *Message) error {
+				_, err := m.Message(nil)
+				return err
+			},
+			expected: -1,
+		},
+		{
+			name:  "int64 as repeated",
+			field: 4,
+			read: func(m *Message) error {
+				_, err := m.RepeatedFixed64(nil)
+				return err
+			},
+			expected: WireTypeLengthDelimited,
+		},
+		{
+			name:  "int64 as iterator",
+			field: 4,
+			read: func(m *Message) error {
+				_, err := m.Iterator(nil)
+				return err
+			},
+			expected: WireTypeLengthDelimited,
+		},
+		{
+			name:  "int64 as group",
+			field: 4,
+			read: func(m *Message) error {
+				_, err := m.Group(nil)
+				return err
+			},
+			expected: WireTypeStartGroup,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := New(data, StrictWireTypes())
+			for msg.Next() {
+				if msg.FieldNumber() != tc.field {
+					msg.Skip()
+					continue
+				}
+
+				err := tc.read(msg)
+				if tc.expected == -1 {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					return
+				}
+
+				if !errors.Is(err, ErrWireTypeMismatch) {
+					t.Fatalf("incorrect error: %v", err)
+				}
+
+				var wterr *WireTypeError
+				if !errors.As(err, &wterr) {
+					t.Fatalf("should be a wire type error: %v", err)
+				}
+
+				if wterr.Expected != tc.expected {
+					t.Errorf("incorrect expected wire type: %v", wterr.Expected)
+				}
+
+				if wterr.Found != msg.WireType() {
+					t.Errorf("incorrect found wire type: %v", wterr.Found)
+				}
+				return
+			}
+
+			t.Fatalf("field not found")
+		})
+	}
+
+	t.Run("not strict by default", func(t *testing.T) {
+		msg := New(data)
+		for msg.Next() {
+			if _, err := msg.Int64(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			msg.Skip()
+			break
+		}
+	})
+
+	t.Run("set strict", func(t *testing.T) {
+		msg := New(data)
+		msg.SetStrictWireTypes(true)
+		msg.Next()
+
+		_, err := msg.Int64()
+		if !errors.Is(err, ErrWireTypeMismatch) {
+			t.Errorf("incorrect error: %v", err)
+		}
+
+		expected := "protoscan: wire type mismatch: expected varint, found 32bit: index 1, field 1, wire type 5"
+		if v := err.Error(); v != expected {
+			t.Errorf("incorrect message: %v", v)
+		}
+	})
+}
+
 func decodeScalar(t testing.TB, data []byte, skip int) *testmsg.Scalar {
 	msg := New(data)
 
@@ -0,0 +1,150 @@
+package protoscan
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+// SearchFixed64 searches the values of a packed repeated fixed64 field, sorted
+// in increasing order, for the target value. It returns the position of the
+// target, or where it would be inserted, and whether it was found.
+// It is a binary search over the data and does not move the iterator,
+// use Seek to move to the position.
+func (i *Iterator) SearchFixed64(target uint64) (int, bool) {
+	return searchFixed(i.Data, 8, target, binary.LittleEndian.Uint64)
+}
+
+// SearchFixed32 searches the values of a sorted packed repeated fixed32 field,
+// see SearchFixed64.
+func (i *Iterator) SearchFixed32(target uint32) (int, bool) {
+	return searchFixed(i.Data, 4, target, binary.LittleEndian.Uint32)
+}
+
+// SearchSfixed64 searches the values of a sorted packed repeated sfixed64 field,
+// see SearchFixed64.
+func (i *Iterator) SearchSfixed64(target int64) (int, bool) {
+	return searchFixed(i.Data, 8, target, func(b []byte) int64 {
+		return int64(binary.LittleEndian.Uint64(b))
+	})
+}
+
+// SearchSfixed32 searches the values of a sorted packed repeated sfixed32 field,
+// see SearchFixed64.
+func (i *Iterator) SearchSfixed32(target int32) (int, bool) {
+	return searchFixed(i.Data, 4, target, func(b []byte) int32 {
+		return int32(binary.LittleEndian.Uint32(b))
+	})
+}
+
+// SearchDouble searches the values of a sorted packed repeated double field,
+// see SearchFixed64. NaN values are ordered before all other values.
+func (i *Iterator) SearchDouble(target float64) (int, bool) {
+	return searchFixed(i.Data, 8, target, func(b []byte) float64 {
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	})
+}
+
+// SearchFloat searches the values of a sorted packed repeated float field,
+// see SearchFixed64. NaN values are ordered before all other values.
+func (i *Iterator) SearchFloat(target float32) (int, bool) {
+	return searchFixed(i.Data, 4, target, func(b []byte) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	})
+}
+
+// SearchInt64 searches the values of a packed repeated int64 field, sorted
+// in increasing order, for the target value. It returns the position of the
+// target, or where it would be inserted, and whether it was found.
+// The first call builds the same checkpoints as Seek, after that it binary
+// searches the checkpoints and only decodes the values after the closest one.
+// It does not move the iterator, use Seek to move to the position.
+// If a value can not be decoded the search stops there and returns its
+// position and false.
+func (i *Iterator) SearchInt64(target int64) (int, bool) {
+	return searchVarint(i, target, func(v uint64) int64 { return int64(v) })
+}
+
+// SearchInt32 searches the values of a sorted packed repeated int32 field,
+// see SearchInt64.
+func (i *Iterator) SearchInt32(target int32) (int, bool) {
+	return searchVarint(i, target, func(v uint64) int32 { return int32(v) })
+}
+
+// SearchUint64 searches the values of a sorted packed repeated uint64 field,
+// see SearchInt64.
+func (i *Iterator) SearchUint64(target uint64) (int, bool) {
+	return searchVarint(i, target, func(v uint64) uint64 { return v })
+}
+
+// SearchUint32 searches the values of a sorted packed repeated uint32 field,
+// see SearchInt64.
+func (i *Iterator) SearchUint32(target uint32) (int, bool) {
+	return searchVarint(i, target, func(v uint64) uint32 { return uint32(v) })
+}
+
+// SearchSint64 searches the values of a sorted packed repeated sint64 field,
+// see SearchInt64.
+func (i *Iterator) SearchSint64(target int64) (int, bool) {
+	return searchVarint(i, target, unZig64)
+}
+
+// SearchSint32 searches the values of a sorted packed repeated sint32 field,
+// see SearchInt64.
+func (i *Iterator) SearchSint32(target int32) (int, bool) {
+	return searchVarint(i, target, func(v uint64) int32 { return int32(unZig64(v)) })
+}
+
+// searchFixed is a binary search over the complete values of the given size.
+func searchFixed[T cmp.Ordered](data []byte, size int, target T, decode func([]byte) T) (int, bool) {
+	n := len(data) / size
+	pos := sort.Search(n, func(j int) bool {
+		return cmp.Compare(decode(data[j*size:]), target) >= 0
+	})
+
+	return pos, pos < n && cmp.Compare(decode(data[pos*size:]), target) == 0
+}
+
+// searchVarint does a binary search over the checkpoints to find the block
+// of values that contains the target, then scans that block. The search stops
+// at a value that can not be decoded and the target is reported as not found.
+func searchVarint[T cmp.Ordered](i *Iterator, target T, decode func(uint64) T) (int, bool) {
+	if !i.indexed {
+		i.buildCheckpoints()
+	}
+
+	// the first checkpoint with a value greater than the target,
+	// so the target must be in the block before it.
+	k := sort.Search(len(i.checkpoints), func(j int) bool {
+		_, v, err := varint64(i.Data, i.checkpoints[j])
+		if err != nil {
+			// the scan of the block before will stop at this value.
+			return true
+		}
+
+		return cmp.Compare(decode(v), target) > 0
+	})
+
+	if k == 0 {
+		return 0, false
+	}
+
+	pos := (k - 1) * checkpointInterval
+	index := i.checkpoints[k-1]
+	for pos < i.varints {
+		next, v, err := varint64(i.Data, index)
+		if err != nil {
+			return pos, false
+		}
+
+		if c := cmp.Compare(decode(v), target); c >= 0 {
+			return pos, c == 0
+		}
+
+		index = next
+		pos++
+	}
+
+	return pos, false
+}
@@ -0,0 +1,128 @@
+package protoscan
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIterator_Search(t *testing.T) {
+	message := &testmsg.Packed{}
+	for i := 0; i < 500; i++ {
+		v := int64(i*i*10 - 100000) // includes multi byte and negative varints
+		message.I64 = append(message.I64, v)
+		message.S32 = append(message.S32, int32(v))
+		message.U64 = append(message.U64, uint64(i*i*10))
+		message.F64 = append(message.F64, uint64(i*3))
+		message.Sf32 = append(message.Sf32, int32(v))
+		message.Dbl = append(message.Dbl, float64(v)/3)
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	msg := New(data)
+	for msg.Next() {
+		iter, err := msg.Iterator(nil)
+		if err != nil {
+			t.Fatalf("unable to create iterator: %v", err)
+		}
+
+		switch msg.FieldNumber() {
+		case 2:
+			for _, v := range message.Dbl {
+				checkSearch(t, message.Dbl, v, iter.SearchDouble)
+				checkSearch(t, message.Dbl, v+0.1, iter.SearchDouble)
+			}
+			checkSearch(t, message.Dbl, -1e10, iter.SearchDouble)
+		case 4:
+			for _, v := range message.I64 {
+				checkSearch(t, message.I64, v, iter.SearchInt64)
+				checkSearch(t, message.I64, v+1, iter.SearchInt64)
+			}
+			checkSearch(t, message.I64, -1e10, iter.SearchInt64)
+		case 6:
+			for _, v := range message.U64 {
+				checkSearch(t, message.U64, v, iter.SearchUint64)
+				checkSearch(t, message.U64, v+1, iter.SearchUint64)
+			}
+		case 7:
+			for _, v := range message.S32 {
+				checkSearch(t, message.S32, v, iter.SearchSint32)
+				checkSearch(t, message.S32, v-1, iter.SearchSint32)
+			}
+		case 10:
+			for _, v := range message.F64 {
+				checkSearch(t, message.F64, v, iter.SearchFixed64)
+				checkSearch(t, message.F64, v+1, iter.SearchFixed64)
+			}
+			checkSearch(t, message.F64, 1e10, iter.SearchFixed64)
+		case 11:
+			for _, v := range message.Sf32 {
+				checkSearch(t, message.Sf32, v, iter.SearchSfixed32)
+				checkSearch(t, message.Sf32, v-1, iter.SearchSfixed32)
+			}
+		}
+
+		if iter.Index != 0 {
+			t.Errorf("search should not move the iterator: %v", iter.Index)
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		iter := &Iterator{}
+		if pos, found := iter.SearchInt64(10); pos != 0 || found {
+			t.Errorf("incorrect result: %v %v", pos, found)
+		}
+
+		if pos, found := iter.SearchFixed32(10); pos != 0 || found {
+			t.Errorf("incorrect result: %v %v", pos, found)
+		}
+	})
+
+	t.Run("invalid varint", func(t *testing.T) {
+		overflow := []byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 1}
+		data := append([]byte{1, 2}, overflow...)
+		data = append(data, 5)
+
+		iter := &Iterator{base: base{Data: data}}
+		if pos, found := iter.SearchInt64(5); pos != 2 || found {
+			t.Errorf("incorrect result: %v %v", pos, found)
+		}
+
+		// the invalid value is at a checkpoint
+		data = nil
+		for i := 0; i < checkpointInterval; i++ {
+			data = append(data, byte(i))
+		}
+		data = append(data, overflow...)
+		data = append(data, 100)
+
+		iter = &Iterator{base: base{Data: data}}
+		if pos, found := iter.SearchInt64(100); pos != checkpointInterval || found {
+			t.Errorf("incorrect result: %v %v", pos, found)
+		}
+
+		if pos, found := iter.SearchInt64(10); pos != 10 || !found {
+			t.Errorf("incorrect result: %v %v", pos, found)
+		}
+	})
+}
+
+func checkSearch[T int32 | int64 | uint64 | float64](t testing.TB, values []T, target T, search func(T) (int, bool)) {
+	t.Helper()
+
+	epos, efound := slices.BinarySearch(values, target)
+	pos, found := search(target)
+	if pos != epos || found != efound {
+		t.Errorf("incorrect search for %v: %v %v != %v %v", target, pos, found, epos, efound)
+	}
+}
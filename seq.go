@@ -0,0 +1,40 @@
+package protoscan
+
+import "iter"
+
+// Fields returns a sequence over the fields of the message for use in a
+// range loop. The yielded message is m positioned at the field, so the body
+// can read the value with any of the accessors. Fields the body does not
+// read are skipped automatically. If the data can not be scanned the error
+// is yielded, with a nil message, as the last value.
+//
+//	for field, err := range msg.Fields() {
+//		if err != nil {
+//			// handle
+//		}
+//
+//		switch field.FieldNumber() {
+//		case 1:
+//			id, err := field.Int64()
+//			...
+//		}
+//	}
+func (m *Message) Fields() iter.Seq2[*Message, error] {
+	return func(yield func(*Message, error) bool) {
+		for m.Next() {
+			index := m.Index
+			if !yield(m, nil) {
+				return
+			}
+
+			if m.Index == index {
+				// value was not read, or there was an error reading it.
+				m.Skip()
+			}
+		}
+
+		if m.err != nil {
+			yield(nil, m.err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessage_Fields(t *testing.T) {
+	message := &testmsg.Packed{
+		I64:   []int64{1, 2, 3},
+		Dbl:   []float64{1.5, 2.5},
+		Str:   []string{"a", "b"},
+		After: proto.Bool(true),
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	result := &testmsg.Packed{}
+
+	msg := New(data)
+	for field, err := range msg.Fields() {
+		if err != nil {
+			t.Fatalf("scanning error: %v", err)
+		}
+
+		switch field.FieldNumber() {
+		case 4:
+			iter, err := field.Iterator(nil)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			for v, err := range iter.Int64s() {
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+
+				result.I64 = append(result.I64, v)
+			}
+		case 14:
+			s, err := field.String()
+			if err != nil {
+				t.Fatalf("unable to read string: %v", err)
+			}
+			result.Str = append(result.Str, s)
+		case 32:
+			v, err := field.Bool()
+			if err != nil {
+				t.Fatalf("unable to read bool: %v", err)
+			}
+			result.After = &v
+		}
+
+		// other fields are skipped automatically
+	}
+
+	message.Dbl = nil
+	compare(t, result, message)
+
+	t.Run("break", func(t *testing.T) {
+		msg := New(data)
+		count := 0
+		for range msg.Fields() {
+			count++
+			break
+		}
+
+		if count != 1 {
+			t.Errorf("incorrect count: %v", count)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		msg := New([]byte{0x08, 0x01, 0x10, 0x80})
+
+		var last error
+		count := 0
+		for field, err := range msg.Fields() {
+			if err != nil {
+				last = err
+				if field != nil {
+					t.Errorf("message should be nil with an error")
+				}
+				continue
+			}
+			count++
+		}
+
+		if count != 2 {
+			t.Errorf("incorrect count: %v", count)
+		}
+
+		if !errors.Is(last, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", last)
+		}
+	})
+}
+
+func TestIterator_sequences(t *testing.T) {
+	message := &testmsg.Packed{
+		Flt:  []float32{1.5, 2.5},
+		S32:  []int32{-1, 2, -3},
+		F64:  []uint64{10, 20},
+		Bool: []bool{true, false},
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	result := &testmsg.Packed{}
+
+	msg := New(data)
+	for field, err := range msg.Fields() {
+		if err != nil {
+			t.Fatalf("scanning error: %v", err)
+		}
+
+		iter, err := field.Iterator(nil)
+		if err != nil {
+			t.Fatalf("unable to create iterator: %v", err)
+		}
+
+		switch field.FieldNumber() {
+		case 1:
+			for v, err := range iter.Floats() {
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				result.Flt = append(result.Flt, v)
+			}
+		case 7:
+			for v, err := range iter.Sint32s() {
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				result.S32 = append(result.S32, v)
+			}
+		case 10:
+			for v, err := range iter.Fixed64s() {
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				result.F64 = append(result.F64, v)
+			}
+		case 13:
+			for v, err := range iter.Bools() {
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				result.Bool = append(result.Bool, v)
+			}
+		}
+	}
+
+	compare(t, result, message)
+
+	t.Run("error", func(t *testing.T) {
+		iter := &Iterator{base: base{Data: []byte{1, 2, 0x80}}}
+
+		var values []int64
+		var last error
+		for v, err := range iter.Int64s() {
+			if err != nil {
+				last = err
+				continue
+			}
+			values = append(values, v)
+		}
+
+		if len(values) != 2 {
+			t.Errorf("incorrect values: %v", values)
+		}
+
+		if !errors.Is(last, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", last)
+		}
+	})
+}
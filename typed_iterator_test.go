@@ -0,0 +1,141 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTypedIterator(t *testing.T) {
+	message := &testmsg.Packed{
+		Dbl:  []float64{1.5, 2.5, 3.5, 4.5},
+		S64:  []int64{-1, 200, -300, 4000},
+		F32:  []uint32{10, 20, 30, 40},
+		Bool: []bool{true, false, true, true},
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	result := &testmsg.Packed{}
+
+	var (
+		diter *DoubleIterator
+		siter *Sint64Iterator
+		fiter *Fixed32Iterator
+		biter *BoolIterator
+	)
+
+	msg := New(data)
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 2:
+			diter, err = msg.DoubleIterator(diter)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			if v := diter.Len(); v != 4 {
+				t.Errorf("incorrect length: %v", v)
+			}
+
+			for v, ok := diter.Next(); ok; v, ok = diter.Next() {
+				result.Dbl = append(result.Dbl, v)
+			}
+
+			if err := diter.Err(); err != nil {
+				t.Fatalf("iterator error: %v", err)
+			}
+		case 8:
+			siter, err = msg.Sint64Iterator(siter)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			if v := siter.FieldNumber(); v != 8 {
+				t.Errorf("incorrect field number: %v", v)
+			}
+
+			for v, ok := siter.Next(); ok; v, ok = siter.Next() {
+				result.S64 = append(result.S64, v)
+			}
+
+			if v := siter.Len(); v != 0 {
+				t.Errorf("incorrect length: %v", v)
+			}
+		case 9:
+			fiter, err = msg.Fixed32Iterator(fiter)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			if err := fiter.Skip(2); err != nil {
+				t.Fatalf("unable to skip: %v", err)
+			}
+
+			if v := fiter.Len(); v != 2 {
+				t.Errorf("incorrect length: %v", v)
+			}
+
+			result.F32 = []uint32{10, 20}
+			for v, ok := fiter.Next(); ok; v, ok = fiter.Next() {
+				result.F32 = append(result.F32, v)
+			}
+
+			if err := fiter.Skip(1); !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("incorrect error: %v", err)
+			}
+
+			if err := fiter.Seek(1); err != nil {
+				t.Fatalf("unable to seek: %v", err)
+			}
+
+			if v, ok := fiter.Next(); !ok || v != 20 {
+				t.Errorf("incorrect value after seek: %v %v", v, ok)
+			}
+
+			fiter.Reset()
+			if v := fiter.Len(); v != 4 {
+				t.Errorf("incorrect length after reset: %v", v)
+			}
+		case 13:
+			biter, err = msg.BoolIterator(biter)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			for v, ok := biter.Next(); ok; v, ok = biter.Next() {
+				result.Bool = append(result.Bool, v)
+			}
+		default:
+			msg.Skip()
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	compare(t, result, message)
+
+	t.Run("error", func(t *testing.T) {
+		iter := &Int64Iterator{iter: Iterator{base: base{Data: []byte{1, 200}}}}
+
+		if v, ok := iter.Next(); !ok || v != 1 {
+			t.Errorf("incorrect value: %v %v", v, ok)
+		}
+
+		if _, ok := iter.Next(); ok {
+			t.Errorf("should be false for truncated value")
+		}
+
+		if err := iter.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+	})
+}
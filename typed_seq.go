@@ -0,0 +1,252 @@
+// Code generated by internal/gen_repeated.go. DO NOT EDIT.
+
+package protoscan
+
+import "iter"
+
+// Floats returns a sequence over the remaining values of the packed repeated
+// field, decoded as float, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Floats() iter.Seq2[float32, error] {
+	return func(yield func(float32, error) bool) {
+		for i.HasNext() {
+			v, err := i.Float()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Doubles returns a sequence over the remaining values of the packed repeated
+// field, decoded as double, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Doubles() iter.Seq2[float64, error] {
+	return func(yield func(float64, error) bool) {
+		for i.HasNext() {
+			v, err := i.Double()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Int32s returns a sequence over the remaining values of the packed repeated
+// field, decoded as int32, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Int32s() iter.Seq2[int32, error] {
+	return func(yield func(int32, error) bool) {
+		for i.HasNext() {
+			v, err := i.Int32()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Int64s returns a sequence over the remaining values of the packed repeated
+// field, decoded as int64, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Int64s() iter.Seq2[int64, error] {
+	return func(yield func(int64, error) bool) {
+		for i.HasNext() {
+			v, err := i.Int64()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Uint32s returns a sequence over the remaining values of the packed repeated
+// field, decoded as uint32, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Uint32s() iter.Seq2[uint32, error] {
+	return func(yield func(uint32, error) bool) {
+		for i.HasNext() {
+			v, err := i.Uint32()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Uint64s returns a sequence over the remaining values of the packed repeated
+// field, decoded as uint64, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Uint64s() iter.Seq2[uint64, error] {
+	return func(yield func(uint64, error) bool) {
+		for i.HasNext() {
+			v, err := i.Uint64()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Sint32s returns a sequence over the remaining values of the packed repeated
+// field, decoded as sint32, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Sint32s() iter.Seq2[int32, error] {
+	return func(yield func(int32, error) bool) {
+		for i.HasNext() {
+			v, err := i.Sint32()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Sint64s returns a sequence over the remaining values of the packed repeated
+// field, decoded as sint64, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Sint64s() iter.Seq2[int64, error] {
+	return func(yield func(int64, error) bool) {
+		for i.HasNext() {
+			v, err := i.Sint64()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Fixed32s returns a sequence over the remaining values of the packed repeated
+// field, decoded as fixed32, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Fixed32s() iter.Seq2[uint32, error] {
+	return func(yield func(uint32, error) bool) {
+		for i.HasNext() {
+			v, err := i.Fixed32()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Fixed64s returns a sequence over the remaining values of the packed repeated
+// field, decoded as fixed64, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Fixed64s() iter.Seq2[uint64, error] {
+	return func(yield func(uint64, error) bool) {
+		for i.HasNext() {
+			v, err := i.Fixed64()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Sfixed32s returns a sequence over the remaining values of the packed repeated
+// field, decoded as sfixed32, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Sfixed32s() iter.Seq2[int32, error] {
+	return func(yield func(int32, error) bool) {
+		for i.HasNext() {
+			v, err := i.Sfixed32()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Sfixed64s returns a sequence over the remaining values of the packed repeated
+// field, decoded as sfixed64, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Sfixed64s() iter.Seq2[int64, error] {
+	return func(yield func(int64, error) bool) {
+		for i.HasNext() {
+			v, err := i.Sfixed64()
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Bools returns a sequence over the remaining values of the packed repeated
+// field, decoded as bool, for use in a range loop. If a value can not be read
+// the error is yielded as the last value.
+func (i *Iterator) Bools() iter.Seq2[bool, error] {
+	return func(yield func(bool, error) bool) {
+		for i.HasNext() {
+			v, err := i.Bool()
+			if err != nil {
+				yield(false, err)
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
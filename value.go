@@ -0,0 +1,145 @@
+package protoscan
+
+import "math"
+
+// A Value is the raw value of a field of any wire type, see Message.Value.
+// It can be used to handle fields without knowing their exact type.
+type Value struct {
+	WireType int
+
+	// Num is the value of varint, 32bit and 64bit fields.
+	Num uint64
+
+	// Bytes is the data of length delimited fields and the encoded fields
+	// of groups. NOTE: this value is NOT copied.
+	Bytes []byte
+
+	// the message the value was read from, so AsMessage
+	// can scan it like an embedded message.
+	path        []int
+	fieldNumber int
+	depth       int
+	strict      bool
+	limits      Limits
+}
+
+// Value returns the value of the current field based on its wire type.
+// Varint, 32bit and 64bit values are returned as a uint64 that can then
+// be converted. Length delimited values and groups are returned as bytes.
+func (m *Message) Value() (Value, error) {
+	v := Value{WireType: m.wireType}
+
+	var err error
+	switch m.wireType {
+	case WireTypeVarint:
+		v.Num, err = m.Varint64()
+	case WireType64bit:
+		v.Num, err = m.Fixed64()
+	case WireType32bit:
+		var n uint32
+		n, err = m.Fixed32()
+		v.Num = uint64(n)
+	case WireTypeLengthDelimited:
+		v.Bytes, err = m.Bytes()
+		v.setParent(m)
+	case WireTypeStartGroup:
+		var end, next int
+		end, next, err = groupEnd(m.Data, m.Index, m.fieldNumber)
+		if err != nil {
+			return Value{}, m.decodeError(err)
+		}
+
+		v.Bytes = m.Data[m.Index:end]
+		v.setParent(m)
+		m.Index = next
+	}
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	return v, nil
+}
+
+// AsInt32 returns the value of an int32 or sfixed32 field.
+func (v Value) AsInt32() int32 {
+	return int32(v.Num)
+}
+
+// AsInt64 returns the value of an int64 or sfixed64 field.
+func (v Value) AsInt64() int64 {
+	return int64(v.Num)
+}
+
+// AsUint32 returns the value of a uint32 or fixed32 field.
+func (v Value) AsUint32() uint32 {
+	return uint32(v.Num)
+}
+
+// AsUint64 returns the value of a uint64 or fixed64 field.
+func (v Value) AsUint64() uint64 {
+	return v.Num
+}
+
+// AsSint32 returns the value of a zig-zag encoded sint32 field.
+func (v Value) AsSint32() int32 {
+	return int32(unZig64(v.Num))
+}
+
+// AsSint64 returns the value of a zig-zag encoded sint64 field.
+func (v Value) AsSint64() int64 {
+	return unZig64(v.Num)
+}
+
+// AsBool returns the value of a bool field.
+func (v Value) AsBool() bool {
+	return v.Num == 1
+}
+
+// AsFloat returns the value of a float field.
+func (v Value) AsFloat() float32 {
+	return math.Float32frombits(uint32(v.Num))
+}
+
+// AsDouble returns the value of a double field.
+func (v Value) AsDouble() float64 {
+	return math.Float64frombits(v.Num)
+}
+
+// AsString returns the value of a string field. The bytes are copied.
+func (v Value) AsString() string {
+	return string(v.Bytes)
+}
+
+// AsMessage returns a Message to scan an embedded message or group.
+// Like Message and Group, it has the strict mode and limits of the message
+// the value was read from and counts towards the MaxDepth limit. If the limit
+// is exceeded the message's Err will return the error. Options, if provided,
+// replace the strict mode and limits.
+func (v Value) AsMessage(opts ...Option) *Message {
+	msg := New(v.Bytes, opts...)
+	if v.fieldNumber == 0 {
+		return msg // not read from a message
+	}
+
+	msg.path = append(append([]int(nil), v.path...), v.fieldNumber)
+	msg.depth = v.depth + 1
+	if len(opts) == 0 {
+		msg.strict = v.strict
+		msg.limits = v.limits
+	}
+
+	if msg.limits.MaxDepth > 0 && msg.depth > msg.limits.MaxDepth {
+		msg.err = msg.decodeError(&LimitError{Limit: "depth", Max: msg.limits.MaxDepth, Value: msg.depth})
+	}
+
+	return msg
+}
+
+func (v *Value) setParent(m *Message) {
+	v.path = m.path
+	v.fieldNumber = m.fieldNumber
+	v.depth = m.depth
+	v.strict = m.strict
+	v.limits = m.limits
+}
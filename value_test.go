@@ -0,0 +1,132 @@
+package protoscan
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessage_Value(t *testing.T) {
+	message := &testmsg.Scalar{
+		Flt:  proto.Float32(1.5),
+		Dbl:  proto.Float64(-2.5),
+		I32:  proto.Int32(-3),
+		I64:  proto.Int64(-4),
+		U32:  proto.Uint32(5),
+		U64:  proto.Uint64(6),
+		S32:  proto.Int32(-7),
+		S64:  proto.Int64(-8),
+		F32:  proto.Uint32(9),
+		F64:  proto.Uint64(10),
+		Sf32: proto.Int32(-11),
+		Sf64: proto.Int64(-12),
+		Bool: proto.Bool(true),
+		Str:  proto.String("abc"),
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	s := &testmsg.Scalar{}
+
+	msg := New(data, StrictWireTypes())
+	for msg.Next() {
+		v, err := msg.Value()
+		if err != nil {
+			t.Fatalf("unable to read value: %v", err)
+		}
+
+		if v.WireType != msg.WireType() {
+			t.Errorf("incorrect wire type: %v != %v", v.WireType, msg.WireType())
+		}
+
+		switch msg.FieldNumber() {
+		case 1:
+			s.Flt = proto.Float32(v.AsFloat())
+		case 2:
+			s.Dbl = proto.Float64(v.AsDouble())
+		case 3:
+			s.I32 = proto.Int32(v.AsInt32())
+		case 4:
+			s.I64 = proto.Int64(v.AsInt64())
+		case 5:
+			s.U32 = proto.Uint32(v.AsUint32())
+		case 6:
+			s.U64 = proto.Uint64(v.AsUint64())
+		case 7:
+			s.S32 = proto.Int32(v.AsSint32())
+		case 8:
+			s.S64 = proto.Int64(v.AsSint64())
+		case 9:
+			s.F32 = proto.Uint32(v.AsUint32())
+		case 10:
+			s.F64 = proto.Uint64(v.AsUint64())
+		case 11:
+			s.Sf32 = proto.Int32(v.AsInt32())
+		case 12:
+			s.Sf64 = proto.Int64(v.AsInt64())
+		case 13:
+			s.Bool = proto.Bool(v.AsBool())
+		case 14:
+			s.Str = proto.String(v.AsString())
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	compare(t, s, message)
+}
+
+func TestMessage_Value_message(t *testing.T) {
+	child := protowire.AppendTag(nil, 1, WireTypeVarint)
+	child = protowire.AppendVarint(child, 100)
+
+	data := protowire.AppendTag(nil, 1, WireTypeLengthDelimited)
+	data = protowire.AppendBytes(data, child)
+	data = protowire.AppendTag(data, 2, WireTypeStartGroup)
+	data = append(data, child...)
+	data = protowire.AppendTag(data, 2, WireTypeEndGroup)
+
+	msg := New(data)
+	for msg.Next() {
+		v, err := msg.Value()
+		if err != nil {
+			t.Fatalf("unable to read value: %v", err)
+		}
+
+		embedded := v.AsMessage()
+		if !embedded.Next() {
+			t.Fatalf("embedded message should have a field")
+		}
+
+		if n, err := embedded.Int64(); err != nil || n != 100 {
+			t.Errorf("incorrect value: %v %v", n, err)
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		msg := New(data[:len(data)-1])
+		for msg.Next() {
+			if _, err := msg.Value(); err != nil {
+				if !errors.Is(err, io.ErrUnexpectedEOF) {
+					t.Errorf("incorrect error: %v", err)
+				}
+				return
+			}
+		}
+
+		t.Errorf("should return an error")
+	})
+}
@@ -0,0 +1,319 @@
+package protoscan
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// A Writer encodes fields in the protobuf wire format by appending
+// them to Data. There is a method for each of the Message accessors.
+// The encoded data can be scanned using New(w.Data).
+// Field numbers must be in the range 1 to 2^29-1, the methods
+// panic if given a field number outside of that range.
+type Writer struct {
+	Data []byte
+
+	// messages is the index of the reserved length of
+	// each embedded message started by BeginMessage.
+	messages []int
+	padded   bool
+}
+
+// reservedLength is the number of bytes reserved for the length of an embedded
+// message. A 5 byte varint can hold lengths up to 2^35-1.
+const reservedLength = 5
+
+// NewWriter returns a writer that will append to the buffer.
+// Use buf[:0] to reuse the memory of a previous message.
+func NewWriter(buf []byte) *Writer {
+	return &Writer{Data: buf}
+}
+
+// SetPaddedLengths will keep the lengths of embedded messages in the space
+// reserved by BeginMessage, as non-minimal 5 byte varints. This is faster
+// since End does not need to move the message data, but the encoding is larger.
+func (w *Writer) SetPaddedLengths(padded bool) {
+	w.padded = padded
+}
+
+// Reset empties the writer so it can be reused, keeping the allocated memory.
+func (w *Writer) Reset() {
+	w.Data = w.Data[:0]
+	w.messages = w.messages[:0]
+}
+
+// BeginMessage starts an embedded message field. The fields written after
+// this, until the matching End, are the fields of the embedded message.
+// Messages can be nested to any depth.
+func (w *Writer) BeginMessage(field int) {
+	w.tag(field, WireTypeLengthDelimited)
+	w.messages = append(w.messages, len(w.Data))
+	w.Data = append(w.Data, make([]byte, reservedLength)...)
+}
+
+// End finishes the embedded message started by the last BeginMessage and writes
+// its length. The message data is moved if the length needs fewer bytes than
+// were reserved, unless padded lengths are used. It will panic if there is no
+// open message.
+func (w *Writer) End() {
+	if len(w.messages) == 0 {
+		panic("protoscan: End called without BeginMessage")
+	}
+
+	start := w.messages[len(w.messages)-1]
+	w.messages = w.messages[:len(w.messages)-1]
+
+	l := uint64(len(w.Data) - start - reservedLength)
+	if w.padded {
+		for i := 0; i < reservedLength-1; i++ {
+			w.Data[start+i] = byte(l) | 0x80
+			l >>= 7
+		}
+		w.Data[start+reservedLength-1] = byte(l)
+		return
+	}
+
+	// the length is written over the reserved bytes, then the data is moved back.
+	length := appendVarint(w.Data[start:start], l)
+	n := copy(w.Data[start+len(length):], w.Data[start+reservedLength:])
+	w.Data = w.Data[:start+len(length)+n]
+}
+
+// Len returns the number of bytes written.
+func (w *Writer) Len() int {
+	return len(w.Data)
+}
+
+// Fixed32 writes a fixed 4 byte value.
+func (w *Writer) Fixed32(field int, v uint32) {
+	w.tag(field, WireType32bit)
+	w.Data = binary.LittleEndian.AppendUint32(w.Data, v)
+}
+
+// Fixed64 writes a fixed 8 byte value.
+func (w *Writer) Fixed64(field int, v uint64) {
+	w.tag(field, WireType64bit)
+	w.Data = binary.LittleEndian.AppendUint64(w.Data, v)
+}
+
+// Sfixed32 writes a fixed 4 byte signed value.
+func (w *Writer) Sfixed32(field int, v int32) {
+	w.Fixed32(field, uint32(v))
+}
+
+// Sfixed64 writes a fixed 8 byte signed value.
+func (w *Writer) Sfixed64(field int, v int64) {
+	w.Fixed64(field, uint64(v))
+}
+
+// Varint32 writes a variable-length encoded value.
+func (w *Writer) Varint32(field int, v uint32) {
+	w.Varint64(field, uint64(v))
+}
+
+// Varint64 writes a variable-length encoded value.
+func (w *Writer) Varint64(field int, v uint64) {
+	w.tag(field, WireTypeVarint)
+	w.Data = appendVarint(w.Data, v)
+}
+
+// Double writes a value as 8 bytes in the IEEE-754 format.
+func (w *Writer) Double(field int, v float64) {
+	w.Fixed64(field, math.Float64bits(v))
+}
+
+// Float writes a value as 4 bytes in the IEEE-754 format.
+func (w *Writer) Float(field int, v float32) {
+	w.Fixed32(field, math.Float32bits(v))
+}
+
+// Int32 writes a variable-length encoded value.
+// Negative values are sign extended and always use 10 bytes.
+func (w *Writer) Int32(field int, v int32) {
+	w.Varint64(field, uint64(int64(v)))
+}
+
+// Int64 writes a variable-length encoded value.
+// Negative values always use 10 bytes.
+func (w *Writer) Int64(field int, v int64) {
+	w.Varint64(field, uint64(v))
+}
+
+// Uint32 writes a variable-length encoded value.
+func (w *Writer) Uint32(field int, v uint32) {
+	w.Varint64(field, uint64(v))
+}
+
+// Uint64 writes a variable-length encoded value.
+func (w *Writer) Uint64(field int, v uint64) {
+	w.Varint64(field, v)
+}
+
+// Sint32 writes a variable-length, zig-zag encoded, value.
+func (w *Writer) Sint32(field int, v int32) {
+	w.Varint64(field, zig64(int64(v)))
+}
+
+// Sint64 writes a variable-length, zig-zag encoded, value.
+func (w *Writer) Sint64(field int, v int64) {
+	w.Varint64(field, zig64(v))
+}
+
+// Bool writes a value as a 1 or 0 varint.
+func (w *Writer) Bool(field int, v bool) {
+	w.Varint64(field, boolVarint(v))
+}
+
+// String writes a length delimited string.
+func (w *Writer) String(field int, v string) {
+	w.tag(field, WireTypeLengthDelimited)
+	w.Data = appendVarint(w.Data, uint64(len(v)))
+	w.Data = append(w.Data, v...)
+}
+
+// Bytes writes a length delimited sequence of bytes.
+func (w *Writer) Bytes(field int, v []byte) {
+	w.tag(field, WireTypeLengthDelimited)
+	w.Data = appendVarint(w.Data, uint64(len(v)))
+	w.Data = append(w.Data, v...)
+}
+
+// CopyField copies the current field of the message, the tag and value
+// bytes, without decoding it and moves the scanner past the field.
+// Groups are copied including all the fields up to the end group tag.
+// Like the other accessors this must be called right after Next.
+func (w *Writer) CopyField(m *Message) error {
+	f, err := m.RawField()
+	if err != nil {
+		return err
+	}
+
+	w.Data = append(w.Data, f.Tag...)
+	w.Data = append(w.Data, f.Value...)
+	return nil
+}
+
+// PackedDeltaSint64 writes the values as a packed repeated sint64 field
+// where each value is stored as the difference from the previous value,
+// see Iterator.DeltaSint64. Nothing is written if there are no values.
+func (w *Writer) PackedDeltaSint64(field int, values []int64) {
+	if len(values) == 0 {
+		return
+	}
+
+	p := w.BeginPacked(field)
+	for _, v := range values {
+		p.DeltaSint64(v)
+	}
+	p.Close()
+}
+
+// PackedDeltaSint32 writes the values as a packed repeated sint32 field
+// where each value is stored as the difference from the previous value,
+// see Iterator.DeltaSint32. Nothing is written if there are no values.
+func (w *Writer) PackedDeltaSint32(field int, values []int32) {
+	if len(values) == 0 {
+		return
+	}
+
+	p := w.BeginPacked(field)
+	for _, v := range values {
+		p.DeltaSint32(v)
+	}
+	p.Close()
+}
+
+// A PackedWriter writes the values of a packed repeated field one at a time,
+// for when the values are not all known up front. The methods for the
+// different types must not be mixed, except DeltaSint64 and DeltaSint32
+// which write sint64 and sint32 values.
+type PackedWriter struct {
+	w        *Writer
+	tagIndex int
+	delta    int64
+}
+
+// BeginPacked starts a packed repeated field. Space is reserved for the
+// length and it is written by Close, the same as BeginMessage and End.
+func (w *Writer) BeginPacked(field int) PackedWriter {
+	p := PackedWriter{w: w, tagIndex: len(w.Data)}
+	w.BeginMessage(field)
+	return p
+}
+
+// DeltaSint64 appends the difference from the previous value as a sint64,
+// see Iterator.DeltaSint64.
+func (p *PackedWriter) DeltaSint64(v int64) {
+	p.w.Data = appendVarint(p.w.Data, zig64(v-p.delta))
+	p.delta = v
+}
+
+// DeltaSint32 appends the difference from the previous value as a sint32,
+// see Iterator.DeltaSint32.
+func (p *PackedWriter) DeltaSint32(v int32) {
+	p.w.Data = appendVarint(p.w.Data, zig64(int64(v-int32(p.delta))))
+	p.delta = int64(v)
+}
+
+// Close writes the length of the packed field. If no values were
+// written the field is removed. Other fields must not be written
+// to the Writer until the packed field is closed. Calling Close
+// again does nothing.
+func (p *PackedWriter) Close() {
+	w := p.w
+	if w == nil {
+		return
+	}
+	p.w = nil
+
+	if !w.removeEmpty(p.tagIndex) {
+		w.End()
+	}
+}
+
+// removeEmpty removes the field started by BeginMessage, whose tag is at
+// tagIndex, if nothing has been written to it. Returns false if the field
+// is not empty and must be ended with End.
+func (w *Writer) removeEmpty(tagIndex int) bool {
+	if len(w.messages) == 0 || w.messages[len(w.messages)-1]+reservedLength != len(w.Data) {
+		return false
+	}
+
+	w.messages = w.messages[:len(w.messages)-1]
+	w.Data = w.Data[:tagIndex]
+	return true
+}
+
+func (w *Writer) tag(field, wireType int) {
+	if field < 1 || field > maxFieldNumber {
+		panic("protoscan: invalid field number")
+	}
+
+	w.Data = appendVarint(w.Data, uint64(field)<<3|uint64(wireType))
+}
+
+func appendVarint(data []byte, v uint64) []byte {
+	for v >= 0x80 {
+		data = append(data, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(data, byte(v))
+}
+
+func varintSize(v uint64) int {
+	// 7 bits per byte, ignoring the leading zeros.
+	return (bits.Len64(v|1) + 6) / 7
+}
+
+func boolVarint(v bool) uint64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func zig64(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
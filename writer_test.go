@@ -0,0 +1,538 @@
+package protoscan
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/paulmach/protoscan/internal/testmsg"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWriter(t *testing.T) {
+	message := &testmsg.Scalar{
+		Flt:   proto.Float32(1.5),
+		Dbl:   proto.Float64(math.Pi),
+		I32:   proto.Int32(-123),
+		I64:   proto.Int64(-1 << 40),
+		U32:   proto.Uint32(math.MaxUint32),
+		U64:   proto.Uint64(math.MaxUint64),
+		S32:   proto.Int32(math.MinInt32),
+		S64:   proto.Int64(math.MinInt64),
+		F32:   proto.Uint32(123456),
+		F64:   proto.Uint64(1 << 60),
+		Sf32:  proto.Int32(-5),
+		Sf64:  proto.Int64(-6),
+		Bool:  proto.Bool(true),
+		Str:   proto.String("hello"),
+		Byte:  []byte{1, 2, 3},
+		After: proto.Bool(false),
+	}
+
+	w := NewWriter(nil)
+	w.Float(1, message.GetFlt())
+	w.Double(2, message.GetDbl())
+	w.Int32(3, message.GetI32())
+	w.Int64(4, message.GetI64())
+	w.Uint32(5, message.GetU32())
+	w.Uint64(6, message.GetU64())
+	w.Sint32(7, message.GetS32())
+	w.Sint64(8, message.GetS64())
+	w.Fixed32(9, message.GetF32())
+	w.Fixed64(10, message.GetF64())
+	w.Sfixed32(11, message.GetSf32())
+	w.Sfixed64(12, message.GetSf64())
+	w.Bool(13, message.GetBool())
+	w.String(14, message.GetStr())
+	w.Bytes(15, message.GetByte())
+	w.Bool(32, message.GetAfter())
+
+	expected, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	if !bytes.Equal(w.Data, expected) {
+		t.Errorf("incorrect data:\n%v\n%v", w.Data, expected)
+	}
+
+	result := &testmsg.Scalar{}
+	err = proto.Unmarshal(w.Data, result)
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %v", err)
+	}
+
+	compare(t, result, message)
+
+	t.Run("scan", func(t *testing.T) {
+		msg := New(w.Data)
+		for msg.Next() {
+			switch msg.FieldNumber() {
+			case 3:
+				if v, err := msg.Int32(); err != nil || v != message.GetI32() {
+					t.Errorf("incorrect int32: %v %v", v, err)
+				}
+			case 8:
+				if v, err := msg.Sint64(); err != nil || v != message.GetS64() {
+					t.Errorf("incorrect sint64: %v %v", v, err)
+				}
+			case 14:
+				if v, err := msg.String(); err != nil || v != message.GetStr() {
+					t.Errorf("incorrect string: %v %v", v, err)
+				}
+			default:
+				msg.Skip()
+			}
+		}
+
+		if err := msg.Err(); err != nil {
+			t.Fatalf("scanning error: %v", err)
+		}
+	})
+
+	t.Run("varint", func(t *testing.T) {
+		w := NewWriter(nil)
+		w.Varint32(1, 300)
+		w.Varint64(2, 1<<63)
+
+		msg := New(w.Data)
+		msg.Next()
+		if v, err := msg.Varint32(); err != nil || v != 300 {
+			t.Errorf("incorrect varint32: %v %v", v, err)
+		}
+
+		msg.Next()
+		if v, err := msg.Varint64(); err != nil || v != 1<<63 {
+			t.Errorf("incorrect varint64: %v %v", v, err)
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		data := w.Data
+		w.Reset()
+		if w.Len() != 0 {
+			t.Errorf("should be empty: %v", w.Len())
+		}
+
+		w.Bool(1, true)
+		if &w.Data[0] != &data[0] {
+			t.Errorf("should reuse the buffer")
+		}
+	})
+
+	t.Run("max field number", func(t *testing.T) {
+		w := NewWriter(nil)
+		w.Int64(1<<29-1, 5)
+
+		msg := New(w.Data)
+		if !msg.Next() || msg.FieldNumber() != 1<<29-1 {
+			t.Errorf("incorrect field: %v %v", msg.FieldNumber(), msg.Err())
+		}
+	})
+
+	t.Run("invalid field number", func(t *testing.T) {
+		for _, field := range []int{0, -1, 1 << 29} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("should panic for %v", field)
+					}
+				}()
+
+				w := NewWriter(nil)
+				w.Int64(field, 5)
+			}()
+		}
+	})
+}
+
+func TestWriter_BeginMessage(t *testing.T) {
+	message := &testmsg.Parent{
+		Child: &testmsg.Child{
+			Number: proto.Int64(123),
+			After:  proto.Bool(true),
+		},
+		After: proto.Bool(true),
+	}
+
+	for i := 0; i < 50; i++ {
+		message.Child.Grandchild = append(message.Child.Grandchild, &testmsg.Grandchild{
+			Number: proto.Int64(int64(i * 1000)),
+			After:  proto.Bool(i%2 == 0),
+		})
+	}
+
+	write := func(w *Writer) {
+		w.BeginMessage(1)
+		w.Int64(100, message.Child.GetNumber())
+		for _, gc := range message.Child.Grandchild {
+			w.BeginMessage(200)
+			w.Int64(1000, gc.GetNumber())
+			w.Bool(32000, gc.GetAfter())
+			w.End()
+		}
+		w.Bool(3200, message.Child.GetAfter())
+		w.End()
+		w.Bool(32, message.GetAfter())
+	}
+
+	expected, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	t.Run("minimal lengths", func(t *testing.T) {
+		w := NewWriter(nil)
+		write(w)
+
+		if !bytes.Equal(w.Data, expected) {
+			t.Errorf("incorrect data:\n%v\n%v", w.Data, expected)
+		}
+	})
+
+	t.Run("padded lengths", func(t *testing.T) {
+		w := NewWriter(nil)
+		w.SetPaddedLengths(true)
+		write(w)
+
+		if l := len(expected) + 51*reservedLength - 2 - 50; len(w.Data) != l {
+			t.Errorf("incorrect length: %v != %v", len(w.Data), l)
+		}
+
+		result := &testmsg.Parent{}
+		err := proto.Unmarshal(w.Data, result)
+		if err != nil {
+			t.Fatalf("unable to unmarshal: %v", err)
+		}
+
+		compare(t, result, message)
+
+		// also scan the non-minimal lengths
+		msg := New(w.Data)
+		msg.Next()
+
+		child, err := msg.Message(nil)
+		if err != nil {
+			t.Fatalf("unable to read message: %v", err)
+		}
+
+		count := 0
+		for child.Next() {
+			if child.FieldNumber() == 200 {
+				count++
+			}
+			child.Skip()
+		}
+
+		if count != 50 {
+			t.Errorf("incorrect count: %v", count)
+		}
+	})
+
+	t.Run("empty message", func(t *testing.T) {
+		w := NewWriter(nil)
+		w.BeginMessage(1)
+		w.End()
+
+		if !bytes.Equal(w.Data, []byte{0x0a, 0x00}) {
+			t.Errorf("incorrect data: %v", w.Data)
+		}
+	})
+
+	t.Run("end without begin", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("should panic")
+			}
+		}()
+
+		w := NewWriter(nil)
+		w.End()
+	})
+}
+
+func TestWriter_Packed(t *testing.T) {
+	message := &testmsg.Packed{
+		Flt:  []float32{1, 2.5, -3},
+		Dbl:  []float64{1.5, math.Inf(1)},
+		I32:  []int32{-1, 2, 300},
+		I64:  []int64{1, -2, 3 << 40},
+		U32:  []uint32{1, math.MaxUint32},
+		U64:  []uint64{1 << 60, 2},
+		S32:  []int32{-1, 2, math.MinInt32},
+		S64:  []int64{-1, 2, math.MaxInt64},
+		F32:  []uint32{10, 20},
+		F64:  []uint64{10, 20, 30},
+		Sf32: []int32{-10, 20},
+		Sf64: []int64{-10, 20, -30},
+		Bool: []bool{true, false, true},
+		Str:  []string{"a"},
+	}
+
+	expected, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	t.Run("slices", func(t *testing.T) {
+		w := NewWriter(nil)
+		w.PackedFloat(1, message.Flt)
+		w.PackedDouble(2, message.Dbl)
+		w.PackedInt32(3, message.I32)
+		w.PackedInt64(4, message.I64)
+		w.PackedUint32(5, message.U32)
+		w.PackedUint64(6, message.U64)
+		w.PackedSint32(7, message.S32)
+		w.PackedSint64(8, message.S64)
+		w.PackedFixed32(9, message.F32)
+		w.PackedFixed64(10, message.F64)
+		w.PackedSfixed32(11, message.Sf32)
+		w.PackedSfixed64(12, message.Sf64)
+		w.PackedBool(13, message.Bool)
+		w.PackedInt64(16, nil) // not written
+		w.String(14, "a")
+
+		if !bytes.Equal(w.Data, expected) {
+			t.Errorf("incorrect data:\n%v\n%v", w.Data, expected)
+		}
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		w := NewWriter(nil)
+
+		p := w.BeginPacked(1)
+		for _, v := range message.Flt {
+			p.Float(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(2)
+		for _, v := range message.Dbl {
+			p.Double(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(3)
+		for _, v := range message.I32 {
+			p.Int32(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(4)
+		for _, v := range message.I64 {
+			p.Int64(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(5)
+		for _, v := range message.U32 {
+			p.Uint32(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(6)
+		for _, v := range message.U64 {
+			p.Uint64(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(7)
+		for _, v := range message.S32 {
+			p.Sint32(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(8)
+		for _, v := range message.S64 {
+			p.Sint64(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(9)
+		for _, v := range message.F32 {
+			p.Fixed32(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(10)
+		for _, v := range message.F64 {
+			p.Fixed64(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(11)
+		for _, v := range message.Sf32 {
+			p.Sfixed32(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(12)
+		for _, v := range message.Sf64 {
+			p.Sfixed64(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(13)
+		for _, v := range message.Bool {
+			p.Bool(v)
+		}
+		p.Close()
+
+		p = w.BeginPacked(16)
+		p.Close() // no values, should be removed
+
+		w.String(14, "a")
+
+		if !bytes.Equal(w.Data, expected) {
+			t.Errorf("incorrect data:\n%v\n%v", w.Data, expected)
+		}
+	})
+
+	t.Run("close twice", func(t *testing.T) {
+		w := NewWriter(nil)
+		w.BeginMessage(1)
+
+		p := w.BeginPacked(2)
+		p.Int64(5)
+		p.Close()
+		p.Close() // should not end the embedded message
+
+		w.Int64(3, 6)
+		w.End()
+
+		msg := New(w.Data)
+		msg.Next()
+		embedded, err := msg.Message(nil)
+		if err != nil {
+			t.Fatalf("unable to read message: %v", err)
+		}
+
+		var fields []int
+		for embedded.Next() {
+			fields = append(fields, embedded.FieldNumber())
+			embedded.Skip()
+		}
+
+		if !reflect.DeepEqual(fields, []int{2, 3}) {
+			t.Errorf("incorrect fields: %v", fields)
+		}
+
+		if msg.Next() {
+			t.Errorf("should only have one top level field")
+		}
+	})
+}
+
+func TestWriter_PackedDeltaSint64(t *testing.T) {
+	values := []int64{100, 105, 90, -3000, -3000, 1 << 40, math.MinInt64}
+
+	w := NewWriter(nil)
+	w.PackedDeltaSint64(8, values)
+
+	w.PackedDeltaSint32(7, []int32{5, -10, math.MaxInt32, math.MinInt32})
+
+	msg := New(w.Data)
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 7:
+			iter, err := msg.Iterator(nil)
+			if err != nil {
+				t.Fatalf("unable to create iterator: %v", err)
+			}
+
+			var result []int32
+			for iter.HasNext() {
+				v, err := iter.DeltaSint32()
+				if err != nil {
+					t.Fatalf("unable to read value: %v", err)
+				}
+				result = append(result, v)
+			}
+
+			if !reflect.DeepEqual(result, []int32{5, -10, math.MaxInt32, math.MinInt32}) {
+				t.Errorf("incorrect values: %v", result)
+			}
+		case 8:
+			result, err := msg.RepeatedDeltaSint64(nil)
+			if err != nil {
+				t.Fatalf("unable to read values: %v", err)
+			}
+
+			if !reflect.DeepEqual(result, values) {
+				t.Errorf("incorrect values: %v", result)
+			}
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+}
+
+func TestWriter_CopyField(t *testing.T) {
+	group := protowire.AppendTag(nil, 1, WireTypeVarint)
+	group = protowire.AppendVarint(group, 5)
+	group = protowire.AppendTag(group, 7, WireTypeStartGroup) // nested group
+	group = protowire.AppendTag(group, 7, WireTypeEndGroup)
+
+	data := protowire.AppendTag(nil, 1, WireTypeVarint)
+	data = protowire.AppendVarint(data, 123)
+	data = protowire.AppendTag(data, 2, WireTypeStartGroup)
+	data = append(data, group...)
+	data = protowire.AppendTag(data, 2, WireTypeEndGroup)
+	data = protowire.AppendTag(data, 3, WireTypeLengthDelimited)
+	data = protowire.AppendString(data, "unchanged")
+	data = protowire.AppendTag(data, 1000, WireType64bit) // unknown field
+	data = protowire.AppendFixed64(data, 99)
+
+	// change field 1 and pass everything else through
+	w := NewWriter(nil)
+	msg := New(data)
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 1:
+			v, err := msg.Int64()
+			if err != nil {
+				t.Fatalf("unable to read value: %v", err)
+			}
+			w.Int64(1, v+1)
+		default:
+			if err := w.CopyField(msg); err != nil {
+				t.Fatalf("unable to copy field: %v", err)
+			}
+		}
+	}
+
+	if err := msg.Err(); err != nil {
+		t.Fatalf("scanning error: %v", err)
+	}
+
+	expected := protowire.AppendTag(nil, 1, WireTypeVarint)
+	expected = protowire.AppendVarint(expected, 124)
+	expected = append(expected, data[2:]...)
+
+	if !bytes.Equal(w.Data, expected) {
+		t.Errorf("incorrect data:\n%v\n%v", w.Data, expected)
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 2, WireTypeStartGroup)
+		data = protowire.AppendTag(data, 1, WireTypeVarint)
+
+		w := NewWriter(nil)
+		msg := New(data)
+		msg.Next()
+
+		if err := w.CopyField(msg); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("incorrect error: %v", err)
+		}
+
+		if w.Len() != 0 {
+			t.Errorf("should not write on error: %v", w.Data)
+		}
+	})
+}